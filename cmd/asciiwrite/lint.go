@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/quard/asciiwrite/pkg/figfont"
+)
+
+type lintOpts struct {
+	Args struct {
+		Files []string `positional-arg-name:"FILE" required:"1"`
+	} `positional-args:"yes"`
+}
+
+// lintFonts validates font files and prints their problems, it returns
+// false if any font is unreadable or has errors
+func lintFonts(opts lintOpts) bool {
+	ok := true
+	for _, fileName := range opts.Args.Files {
+		if !lintFont(fileName) {
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+func lintFont(fileName string) bool {
+	file, err := os.Open(fileName)
+	if err != nil {
+		fmt.Printf("%s: %v\n", fileName, err)
+		return false
+	}
+	defer file.Close()
+
+	loader, err := figfont.NewFileLoader(file)
+	if err != nil {
+		fmt.Printf("%s: %v\n", fileName, err)
+		return false
+	}
+	font, err := loader.Parse()
+	if err != nil {
+		fmt.Printf("%s: %v\n", fileName, err)
+		return false
+	}
+
+	report := figfont.Validate(font)
+	for _, issue := range report.Errors {
+		fmt.Printf("%s: error: %v\n", fileName, issue)
+	}
+	for _, issue := range report.Warnings {
+		fmt.Printf("%s: warning: %v\n", fileName, issue)
+	}
+
+	return !report.HasErrors()
+}
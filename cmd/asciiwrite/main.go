@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/jessevdk/go-flags"
 	"github.com/quard/asciiwrite/internal/rest_api"
@@ -9,7 +10,8 @@ import (
 )
 
 var opts struct {
-	Run rest_api.Opts `command:"run"`
+	Run  rest_api.Opts `command:"run"`
+	Lint lintOpts      `command:"lint" description:"validate font files"`
 }
 
 func main() {
@@ -18,6 +20,13 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if parser.Active != nil && parser.Active.Name == "lint" {
+		if !lintFonts(opts.Lint) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	stor, err := storage.NewFirebaseFontStorage()
 	if err != nil {
 		log.Fatal(err)
@@ -10,6 +10,7 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/go-pkgz/rest"
 	"github.com/quard/asciiwrite/internal/storage"
+	"github.com/quard/asciiwrite/pkg/figfont"
 	"github.com/thedevsaddam/govalidator"
 )
 
@@ -44,10 +45,11 @@ func (srv RestAPIServer) getRouter() chi.Router {
 	router := chi.NewRouter()
 	router.Route("/api/v1", func(r chi.Router) {
 		r.Post("/print/", srv.Print)
+		r.Post("/measure/", srv.Measure)
 		r.Get("/fonts/", srv.FontNames)
 
 		r.With(srv.authMiddleware).Post("/font/upload/", srv.FontUpload)
-		// r.With(srv.authMiddleware).Get("/font/{name}/", srv.GetFont)
+		r.With(srv.authMiddleware).Get("/font/{name}/", srv.FontDownload)
 		// r.With(srv.authMiddleware).Delete("/font/{name}/", srv.GetFont)
 	})
 
@@ -88,3 +90,8 @@ func responseBadRequest(response http.ResponseWriter, request *http.Request, err
 	response.WriteHeader(http.StatusBadRequest)
 	rest.RenderJSON(response, request, rest.JSON{"error": err.Error()})
 }
+
+func responseParseError(response http.ResponseWriter, request *http.Request, err *figfont.ParseError) {
+	response.WriteHeader(http.StatusBadRequest)
+	rest.RenderJSON(response, request, rest.JSON{"error": err.Error(), "parseError": err})
+}
@@ -0,0 +1,42 @@
+package rest_api
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/quard/asciiwrite/internal/storage"
+	"github.com/quard/asciiwrite/pkg/figfont"
+)
+
+var ErrUnableToWriteFont = errors.New("unable to write font")
+
+func (srv RestAPIServer) FontDownload(response http.ResponseWriter, request *http.Request) {
+	fontName := chi.URLParam(request, "name")
+	font, err := srv.storage.Get(fontName)
+	if err == storage.ErrFontNotFound {
+		response.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Printf("unable to retrieve font: %v", err)
+		responseBadRequest(response, request, errors.New("unable to retrieve font"))
+		return
+	}
+
+	var fontFile bytes.Buffer
+	writer, err := figfont.NewFileWriter(&fontFile)
+	if err == nil {
+		err = writer.Write(font)
+	}
+	if err != nil {
+		log.Printf("unable to write font '%s': %v", fontName, err)
+		responseBadRequest(response, request, ErrUnableToWriteFont)
+		return
+	}
+
+	response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	response.Header().Set("Content-Disposition", "attachment; filename=\""+fontName+".flf\"")
+	response.Write(fontFile.Bytes())
+}
@@ -0,0 +1,59 @@
+package rest_api
+
+import (
+	"net/http"
+
+	"github.com/go-pkgz/rest"
+	"github.com/quard/asciiwrite/internal/storage"
+	"github.com/quard/asciiwrite/pkg/figfont"
+	"github.com/thedevsaddam/govalidator"
+)
+
+// Measure responds with size of phrase rendered according to print request
+func (srv RestAPIServer) Measure(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+
+	var requestData printRequest
+	opts := govalidator.Options{
+		Request: request,
+		Rules:   printRules,
+		Data:    &requestData,
+	}
+	validator := govalidator.New(opts)
+	validationError := validator.ValidateJSON()
+	if len(validationError) > 0 {
+		responseValidationErrors(response, validationError)
+	} else {
+		size, err := getPhraseSize(srv.storage, requestData)
+		if err != nil {
+			responseBadRequest(response, request, err)
+		} else {
+			rest.RenderJSON(response, request, size)
+		}
+	}
+}
+
+// getPhraseSize measures phrase of print request. Transforms like crop
+// change size according to rows of rendering, so phrase is rendered if
+// transforms are requested
+func getPhraseSize(stor storage.FontStorage, requestData printRequest) (figfont.Size, error) {
+	font, opts, err := getPrintFonts(stor, requestData)
+	if err != nil {
+		return figfont.Size{}, err
+	}
+	transforms, err := requestData.transforms()
+	if err != nil {
+		return figfont.Size{}, err
+	}
+	if len(transforms) == 0 {
+		return font.Measure(requestData.Phrase, opts)
+	}
+
+	rendering, err := font.Render(requestData.Phrase, opts)
+	if err != nil {
+		return figfont.Size{}, err
+	}
+	rendering = rendering.Apply(transforms...)
+
+	return figfont.Size{Width: rendering.Width, Height: rendering.Height}, nil
+}
@@ -2,30 +2,126 @@ package rest_api
 
 import (
 	"errors"
+	"fmt"
+	"image/color"
 	"log"
 	"net/http"
+	"unicode/utf8"
 
 	"github.com/quard/asciiwrite/internal/storage"
+	"github.com/quard/asciiwrite/pkg/figfont"
 	"github.com/thedevsaddam/govalidator"
 )
 
 var ErrUnableToPrint = errors.New("unable to print phrase")
 
+var justifications = map[string]figfont.Justification{
+	"":       figfont.JustifyAuto,
+	"auto":   figfont.JustifyAuto,
+	"left":   figfont.JustifyLeft,
+	"center": figfont.JustifyCenter,
+	"right":  figfont.JustifyRight,
+}
+
+var directions = map[string]figfont.Direction{
+	"":     figfont.DirectionAuto,
+	"auto": figfont.DirectionAuto,
+	"ltr":  figfont.LeftToRight,
+	"rtl":  figfont.RightToLeft,
+}
+
+// missingGlyphs maps handling of absent characters, unknown characters
+// don't fail printing by default
+var missingGlyphs = map[string]figfont.MissingGlyph{
+	"":            figfont.MissingZeroGlyph,
+	"error":       figfont.MissingError,
+	"skip":        figfont.MissingSkip,
+	"placeholder": figfont.MissingPlaceholder,
+	"zero":        figfont.MissingZeroGlyph,
+}
+
+// colorModes maps palettes of ANSI colors, 256 colors are supported by
+// most terminals
+var colorModes = map[string]figfont.ColorMode{
+	"":          figfont.Color256,
+	"16":        figfont.Color16,
+	"256":       figfont.Color256,
+	"truecolor": figfont.TrueColor,
+}
+
+// transformFilters maps geometric transforms of rendering
+var transformFilters = map[string]figfont.Filter{
+	"flip":   figfont.Flip(),
+	"flop":   figfont.Flop(),
+	"rotate": figfont.Rotate180(),
+	"crop":   figfont.Crop(),
+	"border": figfont.Border(),
+}
+
+// maxTransforms limits count of transforms applied to a single rendering
+const maxTransforms = 10
+
+// maxFallbacks limits length of font chain loaded for a single request
+const maxFallbacks = 5
+
 type printRequest struct {
-	Name   string `json:"name"`
-	Phrase string `json:"phrase"`
+	Name          string   `json:"name"`
+	Phrase        string   `json:"phrase"`
+	Width         int      `json:"width"`
+	Justify       string   `json:"justify"`
+	Direction     string   `json:"direction"`
+	Missing       string   `json:"missing"`
+	Placeholder   string   `json:"placeholder"`
+	Fallbacks     []string `json:"fallbacks"`
+	Transliterate bool     `json:"transliterate"`
+	Transforms    []string `json:"transforms"`
+	Color         string   `json:"color"`
+	Gradient      []string `json:"gradient"`
+	ColorMode     string   `json:"colorMode"`
+	FontFamily    string   `json:"fontFamily"`
+	FontSize      int      `json:"fontSize"`
+	Foreground    string   `json:"foreground"`
+	Background    string   `json:"background"`
+	Padding       int      `json:"padding"`
+	Scale         int      `json:"scale"`
+	Format        string   `json:"format"`
+	Animation     string   `json:"animation"`
+	Cursor        bool     `json:"cursor"`
+	MarqueeWidth  int      `json:"marqueeWidth"`
+	Delay         int      `json:"delay"`
+	Loops         int      `json:"loops"`
+}
+
+// printRules validates print request
+var printRules = govalidator.MapData{
+	"name":         []string{"required", "alpha_space", "between:2,20"},
+	"phrase":       []string{"required"},
+	"width":        []string{"numeric_between:0,1000"},
+	"justify":      []string{"in:auto,left,center,right"},
+	"direction":    []string{"in:auto,ltr,rtl"},
+	"missing":      []string{"in:error,skip,placeholder,zero"},
+	"fallbacks":    []string{fmt.Sprintf("max:%d", maxFallbacks)},
+	"transforms":   []string{fmt.Sprintf("max:%d", maxTransforms)},
+	"color":        []string{"in:gay,rainbow,metal,gradient"},
+	"gradient":     []string{"max:2"},
+	"colorMode":    []string{"in:16,256,truecolor"},
+	"fontFamily":   []string{"max:100"},
+	"fontSize":     []string{"numeric_between:0,200"},
+	"padding":      []string{"numeric_between:0,200"},
+	"scale":        []string{fmt.Sprintf("numeric_between:0,%d", maxScale)},
+	"format":       []string{"in:text,svg,png,gif"},
+	"animation":    []string{"in:typewriter,marquee,blink"},
+	"marqueeWidth": []string{"numeric_between:0,1000"},
+	"delay":        []string{"numeric_between:0,1000"},
+	"loops":        []string{"numeric_between:0,100"},
 }
 
 func (srv RestAPIServer) Print(response http.ResponseWriter, request *http.Request) {
 	var requestData printRequest
 
-	rules := govalidator.MapData{
-		"name":   []string{"required", "alpha_space", "between:2,20"},
-		"phrase": []string{"required"},
-	}
 	opts := govalidator.Options{
 		Request: request,
-		Rules:   rules,
+		Rules:   printRules,
 		Data:    &requestData,
 	}
 	validator := govalidator.New(opts)
@@ -33,7 +129,22 @@ func (srv RestAPIServer) Print(response http.ResponseWriter, request *http.Reque
 	if len(validationError) > 0 {
 		responseValidationErrors(response, validationError)
 	} else {
-		text, err := getPrintedPhrase(srv.storage, requestData.Name, requestData.Phrase)
+		format := printFormat(request, requestData)
+		if requestData.Animation != "" && format != "gif" {
+			responseBadRequest(response, request, ErrAnimationFormat)
+			return
+		}
+
+		switch format {
+		case "svg":
+			srv.printSVG(response, request, requestData)
+			return
+		case "png", "gif":
+			srv.printImage(response, request, requestData, format)
+			return
+		}
+
+		text, err := getPrintedPhrase(srv.storage, requestData)
 		if err != nil {
 			responseBadRequest(response, request, err)
 		} else {
@@ -42,14 +153,150 @@ func (srv RestAPIServer) Print(response http.ResponseWriter, request *http.Reque
 	}
 }
 
-func getPrintedPhrase(stor storage.FontStorage, fontName, phrase string) (string, error) {
+// printOptions converts print request to options of font rendering
+func (requestData printRequest) printOptions() figfont.PrintOptions {
+	printOpts := figfont.PrintOptions{
+		Width:         requestData.Width,
+		Justify:       justifications[requestData.Justify],
+		Direction:     directions[requestData.Direction],
+		Missing:       missingGlyphs[requestData.Missing],
+		Transliterate: requestData.Transliterate,
+	}
+	if placeholder, _ := utf8.DecodeRuneInString(requestData.Placeholder); placeholder != utf8.RuneError {
+		printOpts.Placeholder = placeholder
+	}
+
+	return printOpts
+}
+
+func getPrintedPhrase(stor storage.FontStorage, requestData printRequest) (string, error) {
+	rendering, err := getRendering(stor, requestData)
+	if err != nil {
+		return "", err
+	}
+	if requestData.Color == "" {
+		return rendering.String(), nil
+	}
+
+	return rendering.ANSI(colorModes[requestData.ColorMode]), nil
+}
+
+// getRendering renders phrase of print request and applies its transforms
+// and colors
+func getRendering(stor storage.FontStorage, requestData printRequest) (figfont.Rendering, error) {
+	font, opts, err := getPrintFonts(stor, requestData)
+	if err != nil {
+		return figfont.Rendering{}, err
+	}
+	filters, err := requestData.filters()
+	if err != nil {
+		return figfont.Rendering{}, err
+	}
+	rendering, err := font.Render(requestData.Phrase, opts)
+	if err != nil {
+		return rendering, err
+	}
+
+	return rendering.Apply(filters...), nil
+}
+
+// filters returns transforms of print request in requested order followed
+// by color filter
+func (requestData printRequest) filters() ([]figfont.Filter, error) {
+	filters, err := requestData.transforms()
+	if err != nil {
+		return nil, err
+	}
+
+	if requestData.Color != "" {
+		filter, err := requestData.colorFilter()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// transforms returns transforms of print request in requested order
+func (requestData printRequest) transforms() ([]figfont.Filter, error) {
+	var filters []figfont.Filter
+	for _, name := range requestData.Transforms {
+		filter, ok := transformFilters[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown transform '%s'", name)
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// colorFilter returns filter painting rendering with colors of print request
+func (requestData printRequest) colorFilter() (figfont.Filter, error) {
+	switch requestData.Color {
+	case "gay", "rainbow":
+		return figfont.Rainbow(), nil
+	case "metal":
+		return figfont.Metal(), nil
+	}
+
+	if len(requestData.Gradient) != 2 {
+		return nil, errors.New("gradient requires two colors")
+	}
+	from, err := parseHexColor(requestData.Gradient[0])
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseHexColor(requestData.Gradient[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return figfont.Gradient(from, to), nil
+}
+
+// parseHexColor parses color in #rrggbb notation
+func parseHexColor(value string) (color.RGBA, error) {
+	c := color.RGBA{A: 255}
+	if len(value) != 7 || value[0] != '#' {
+		return c, fmt.Errorf("bad color '%s', expected #rrggbb", value)
+	}
+	if _, err := fmt.Sscanf(value, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+		return c, fmt.Errorf("bad color '%s', expected #rrggbb", value)
+	}
+
+	return c, nil
+}
+
+// getPrintFonts retrieves font of print request and adds its fallback
+// fonts to print options
+func getPrintFonts(stor storage.FontStorage, requestData printRequest) (figfont.FIGFont, figfont.PrintOptions, error) {
+	opts := requestData.printOptions()
+	font, err := getFont(stor, requestData.Name)
+	if err != nil {
+		return font, opts, err
+	}
+	for _, fallbackName := range requestData.Fallbacks {
+		fallback, err := getFont(stor, fallbackName)
+		if err != nil {
+			return font, opts, err
+		}
+		opts.Fallbacks = append(opts.Fallbacks, fallback)
+	}
+
+	return font, opts, nil
+}
+
+func getFont(stor storage.FontStorage, fontName string) (figfont.FIGFont, error) {
 	font, err := stor.Get(fontName)
 	if err == storage.ErrFontNotFound {
-		return "", err
+		return font, err
 	} else if err != nil {
 		log.Printf("unable to retrieve font: %v", err)
-		return "", errors.New("unable to retrieve font")
+		return font, errors.New("unable to retrieve font")
 	}
 
-	return font.Print(phrase)
+	return font, nil
 }
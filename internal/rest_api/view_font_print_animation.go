@@ -0,0 +1,68 @@
+package rest_api
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"log"
+
+	"github.com/quard/asciiwrite/pkg/figfont"
+)
+
+// maxAnimationPixels limits total area of frames of animation
+const maxAnimationPixels = 64 << 20
+
+var ErrAnimationFormat = errors.New("animation is supported only by gif format")
+var ErrAnimationTooLarge = errors.New("animation is too large, reduce scale, phrase or marquee width")
+
+// animationFrames returns frames of animation of print request
+func animationFrames(rendering figfont.Rendering, requestData printRequest) []figfont.Rendering {
+	switch requestData.Animation {
+	case "marquee":
+		return rendering.Marquee(requestData.MarqueeWidth)
+	case "blink":
+		return rendering.BlinkingCursor()
+	default:
+		return rendering.Typewriter(requestData.Cursor)
+	}
+}
+
+// animationSize returns count of frames of animation of print request and
+// size of their images without generating frames
+func animationSize(rendering figfont.Rendering, requestData printRequest, opts figfont.ImageOptions) (int, image.Point) {
+	switch requestData.Animation {
+	case "marquee":
+		return rendering.MarqueeSize(requestData.MarqueeWidth, opts)
+	case "blink":
+		return rendering.BlinkingCursorSize(opts)
+	default:
+		return rendering.TypewriterSize(requestData.Cursor, opts)
+	}
+}
+
+// getAnimation encodes animation of rendering as animated GIF. Loops of
+// print request count plays of animation, it is played forever by default.
+// Size of animation is checked before frames are generated
+func getAnimation(rendering figfont.Rendering, requestData printRequest, opts figfont.ImageOptions) ([]byte, error) {
+	count, size := animationSize(rendering, requestData, opts)
+	if pixels := size.X * size.Y; pixels > maxAnimationPixels || count*pixels > maxAnimationPixels {
+		return nil, ErrAnimationTooLarge
+	}
+	frames := animationFrames(rendering, requestData)
+
+	animOpts := figfont.AnimationOptions{Delay: requestData.Delay}
+	switch {
+	case requestData.Loops == 1:
+		animOpts.LoopCount = -1
+	case requestData.Loops > 1:
+		animOpts.LoopCount = requestData.Loops - 1
+	}
+
+	var animation bytes.Buffer
+	if err := figfont.AnimatedGIF(&animation, frames, opts, animOpts); err != nil {
+		log.Printf("unable to encode animation: %v", err)
+		return nil, ErrUnableToPrint
+	}
+
+	return animation.Bytes(), nil
+}
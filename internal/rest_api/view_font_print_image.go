@@ -0,0 +1,175 @@
+package rest_api
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/quard/asciiwrite/internal/storage"
+	"github.com/quard/asciiwrite/pkg/figfont"
+)
+
+// mediaTypes maps output formats of print endpoint, formats of images are
+// also selected by Accept header in order of imageFormats
+var mediaTypes = map[string]string{
+	"text": "text/plain",
+	"svg":  "image/svg+xml",
+	"png":  "image/png",
+	"gif":  "image/gif",
+}
+
+var imageFormats = []string{"svg", "png", "gif"}
+
+// maxScale limits pixel size of bitmap font and maxImagePixels limits
+// area of raster image
+const (
+	maxScale       = 16
+	maxImagePixels = 16 << 20
+)
+
+var ErrImageTooLarge = errors.New("image is too large, reduce scale or phrase")
+
+// printFormat returns output format set by print request or the first
+// image format accepted by client. Animations are printed as GIF, text is
+// printed by default
+func printFormat(request *http.Request, requestData printRequest) string {
+	if requestData.Format != "" {
+		return requestData.Format
+	}
+	if requestData.Animation != "" {
+		return "gif"
+	}
+	for _, format := range imageFormats {
+		if acceptsMediaType(request, mediaTypes[format]) {
+			return format
+		}
+	}
+
+	return "text"
+}
+
+// printSVG responds with phrase of print request rendered as SVG image
+func (srv RestAPIServer) printSVG(response http.ResponseWriter, request *http.Request, requestData printRequest) {
+	svg, err := getPrintedSVG(srv.storage, requestData)
+	if err != nil {
+		responseBadRequest(response, request, err)
+		return
+	}
+
+	response.Header().Set("Content-Type", mediaTypes["svg"])
+	response.Write([]byte(svg))
+}
+
+// printImage responds with phrase of print request drawn as raster image
+// in PNG or GIF format
+func (srv RestAPIServer) printImage(response http.ResponseWriter, request *http.Request, requestData printRequest, format string) {
+	img, err := getPrintedImage(srv.storage, requestData, format)
+	if err != nil {
+		responseBadRequest(response, request, err)
+		return
+	}
+
+	response.Header().Set("Content-Type", mediaTypes[format])
+	response.Write(img)
+}
+
+func getPrintedSVG(stor storage.FontStorage, requestData printRequest) (string, error) {
+	opts, err := requestData.svgOptions()
+	if err != nil {
+		return "", err
+	}
+	rendering, err := getRendering(stor, requestData)
+	if err != nil {
+		return "", err
+	}
+
+	return rendering.SVG(opts), nil
+}
+
+func getPrintedImage(stor storage.FontStorage, requestData printRequest, format string) ([]byte, error) {
+	opts, err := requestData.imageOptions()
+	if err != nil {
+		return nil, err
+	}
+	rendering, err := getRendering(stor, requestData)
+	if err != nil {
+		return nil, err
+	}
+	if format == "gif" && requestData.Animation != "" {
+		return getAnimation(rendering, requestData, opts)
+	}
+	if size := rendering.ImageSize(opts); size.X*size.Y > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	var img bytes.Buffer
+	if format == "gif" {
+		err = rendering.GIF(&img, opts)
+	} else {
+		err = rendering.PNG(&img, opts)
+	}
+	if err != nil {
+		log.Printf("unable to encode %s image: %v", format, err)
+		return nil, ErrUnableToPrint
+	}
+
+	return img.Bytes(), nil
+}
+
+// svgOptions converts print request to settings of SVG image
+func (requestData printRequest) svgOptions() (figfont.SVGOptions, error) {
+	opts := figfont.SVGOptions{
+		FontFamily: requestData.FontFamily,
+		FontSize:   requestData.FontSize,
+		Padding:    requestData.Padding,
+	}
+
+	var err error
+	opts.Foreground, opts.Background, err = requestData.imageColors()
+
+	return opts, err
+}
+
+// imageOptions converts print request to settings of raster image
+func (requestData printRequest) imageOptions() (figfont.ImageOptions, error) {
+	opts := figfont.ImageOptions{
+		Scale:   requestData.Scale,
+		Padding: requestData.Padding,
+	}
+
+	var err error
+	opts.Foreground, opts.Background, err = requestData.imageColors()
+
+	return opts, err
+}
+
+// imageColors parses foreground and background colors of print request,
+// colors which are not set are left transparent so defaults are used
+func (requestData printRequest) imageColors() (foreground, background color.RGBA, err error) {
+	if requestData.Foreground != "" {
+		if foreground, err = parseHexColor(requestData.Foreground); err != nil {
+			return
+		}
+	}
+	if requestData.Background != "" {
+		background, err = parseHexColor(requestData.Background)
+	}
+
+	return
+}
+
+// acceptsMediaType reports whether Accept header of request lists media type
+func acceptsMediaType(request *http.Request, mediaType string) bool {
+	for _, accepted := range strings.Split(request.Header.Get("Accept"), ",") {
+		acceptedType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && acceptedType == mediaType {
+			return true
+		}
+	}
+
+	return false
+}
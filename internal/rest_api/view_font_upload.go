@@ -1,21 +1,26 @@
 package rest_api
 
 import (
+	"encoding/base64"
 	"errors"
+	"io"
 	"log"
 	"net/http"
 	"strings"
 
+	"github.com/go-pkgz/rest"
 	"github.com/quard/asciiwrite/internal/storage"
 	"github.com/quard/asciiwrite/pkg/figfont"
 	"github.com/thedevsaddam/govalidator"
 )
 
 var ErrUnableToParseFont = errors.New("unable to process font")
+var ErrInvalidFont = errors.New("font has errors")
 
 type fontUploadRequest struct {
-	Name string `json:"name"`
-	Font string `json:"font"`
+	Name     string `json:"name"`
+	Font     string `json:"font"`
+	Encoding string `json:"encoding"`
 }
 
 func (srv RestAPIServer) FontUpload(response http.ResponseWriter, request *http.Request) {
@@ -23,8 +28,9 @@ func (srv RestAPIServer) FontUpload(response http.ResponseWriter, request *http.
 
 	var requestData fontUploadRequest
 	rules := govalidator.MapData{
-		"name": []string{"required", "alpha_space", "between:2,20", "font_not_exists"},
-		"font": []string{"required"},
+		"name":     []string{"required", "alpha_space", "between:2,20", "font_not_exists"},
+		"font":     []string{"required"},
+		"encoding": []string{"in:base64"},
 	}
 	opts := govalidator.Options{
 		Request: request,
@@ -36,30 +42,55 @@ func (srv RestAPIServer) FontUpload(response http.ResponseWriter, request *http.
 	if len(validationError) > 0 {
 		responseValidationErrors(response, validationError)
 	} else {
-		err := addNewFont(srv.storage, requestData.Name, requestData.Font)
-		if err != nil {
+		report, err := addNewFont(srv.storage, requestData.Name, requestData.Font, requestData.Encoding)
+		if parseErr, ok := err.(*figfont.ParseError); ok {
+			log.Printf("unable to upload new font: %v", err)
+			responseParseError(response, request, parseErr)
+		} else if err == ErrInvalidFont {
+			log.Printf("unable to upload new font: %v", err)
+			response.WriteHeader(http.StatusBadRequest)
+			rest.RenderJSON(response, request, rest.JSON{"error": err.Error(), "validation": report})
+		} else if err != nil {
 			log.Printf("unable to upload new font: %v", err)
 			responseBadRequest(response, request, err)
 		} else {
 			response.WriteHeader(http.StatusCreated)
+			rest.RenderJSON(response, request, rest.JSON{"warnings": report.Warnings})
 		}
 	}
 }
 
-func addNewFont(storage storage.FontStorage, fontName, fontData string) error {
-	fontLoader, errLoader := figfont.NewFileLoader(strings.NewReader(fontData))
+// addNewFont parses and validates font, font without errors is stored and
+// warnings of validation are returned
+func addNewFont(storage storage.FontStorage, fontName, fontData, encoding string) (figfont.ValidationReport, error) {
+	var fontReader io.Reader = strings.NewReader(fontData)
+	if encoding == "base64" {
+		// binary fonts such as ZIP compressed ones are uploaded as base64
+		fontReader = base64.NewDecoder(base64.StdEncoding, fontReader)
+	}
+
+	fontLoader, errLoader := figfont.NewFileLoader(fontReader)
 	if errLoader != nil {
 		log.Printf("unable to create file font loader: %v", errLoader)
-		return ErrUnableToParseFont
+		return figfont.ValidationReport{}, ErrUnableToParseFont
 	}
 	font, errParse := fontLoader.Parse()
 	if errParse != nil {
 		log.Printf("unable to parse font with file loader: %v", errParse)
-		return ErrUnableToParseFont
+		if _, ok := errParse.(*figfont.ParseError); ok {
+			// details help uploader to fix font file
+			return figfont.ValidationReport{}, errParse
+		}
+		return figfont.ValidationReport{}, ErrUnableToParseFont
 	}
 	font.Name = fontName
 
+	report := figfont.Validate(font)
+	if report.HasErrors() {
+		return report, ErrInvalidFont
+	}
+
 	err := storage.Add(font)
 
-	return err
+	return report, err
 }
@@ -16,12 +16,18 @@ type FirebaseFontStorage struct {
 }
 
 type firebaseFIGFont struct {
-	Name           string     `json:"name"`
-	Hardblank      string     `json:"hardblank"`
-	Height         int        `json:"height"`
-	Baseline       int        `json:"baseline"`
-	PrintDirection int        `json:"printDirection"`
-	Letters        [][]string `json:"letters"`
+	Name           string         `json:"name"`
+	Hardblank      string         `json:"hardblank"`
+	Height         int            `json:"height"`
+	Baseline       int            `json:"baseline"`
+	PrintDirection int            `json:"printDirection"`
+	Layout         figfont.Layout `json:"layout"`
+	MaxLength      int            `json:"maxLength"`
+	CodetagCount   int            `json:"codetagCount"`
+	Comment        string         `json:"comment"`
+	Letters        [][]string     `json:"letters"`
+	// CodetagComments is keyed by code, sparse codes are stored as object
+	CodetagComments map[int]string `json:"codetagComments"`
 }
 
 // NewFirebaseFontStorage connect to Firebase and return instance of font storage
@@ -57,7 +63,8 @@ func (stor FirebaseFontStorage) Get(name string) (figfont.FIGFont, error) {
 	var font figfont.FIGFont
 
 	fontRef := stor.db.NewRef("fonts")
-	ctx, _ := context.WithTimeout(context.Background(), 2*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
 	fonts, err := fontRef.OrderByChild("name").EqualTo(name).GetOrdered(ctx)
 	if err != nil {
 		return font, err
@@ -83,7 +90,8 @@ func (stor FirebaseFontStorage) Get(name string) (figfont.FIGFont, error) {
 
 func (stor FirebaseFontStorage) IsExist(name string) (bool, error) {
 	fontRef := stor.db.NewRef("fonts")
-	ctx, _ := context.WithTimeout(context.Background(), 2*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
 	fonts, err := fontRef.OrderByChild("name").EqualTo(name).GetOrdered(ctx)
 	if err != nil {
 		return false, err
@@ -95,7 +103,8 @@ func (stor FirebaseFontStorage) IsExist(name string) (bool, error) {
 func (stor FirebaseFontStorage) Names() ([]string, error) {
 	var names []string
 	fontRef := stor.db.NewRef("fonts")
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 	fonts, err := fontRef.OrderByChild("name").GetOrdered(ctx)
 	if err != nil {
 		return []string{}, err
@@ -121,6 +130,11 @@ func (fFont firebaseFIGFont) FIGFont() (font figfont.FIGFont) {
 	font.Height = fFont.Height
 	font.Baseline = fFont.Baseline
 	font.PrintDirection = fFont.PrintDirection
+	font.Layout = fFont.Layout
+	font.MaxLength = fFont.MaxLength
+	font.CodetagCount = fFont.CodetagCount
+	font.Comment = fFont.Comment
+	font.CodetagComments = fFont.CodetagComments
 	font.Letters = make(map[int][]string)
 	for num, letter := range fFont.Letters {
 		if letter != nil {
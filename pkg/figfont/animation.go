@@ -0,0 +1,365 @@
+package figfont
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"sort"
+)
+
+const (
+	// cursorChar is sub-character drawing cursor of animations
+	cursorChar = '█'
+	// cursorBlinks is count of cursor blinks ending typewriter animation
+	cursorBlinks = 2
+	// defaultFrameDelay is delay between frames in hundredths of second
+	defaultFrameDelay = 10
+)
+
+// AnimationOptions are timing settings of animated GIF
+type AnimationOptions struct {
+	// Delay is delay between frames in hundredths of second, 10 by default
+	Delay int
+	// LoopCount is count of animation repeats after the first play as in
+	// image/gif: 0 loops forever and -1 plays animation once
+	LoopCount int
+}
+
+// revealStep is area of rendering revealed by typewriter at once: cells
+// of a phrase character or a column if rendering has no spans
+type revealStep struct {
+	areas []image.Rectangle
+}
+
+// Typewriter returns frames revealing characters of phrase one by one
+// starting from blank frame. Cursor is drawn after the last revealed
+// character in print direction and blinks at the end, it takes extra
+// column of frames. Rendering without spans, e.g. flopped one, is revealed
+// column by column
+func (rendering Rendering) Typewriter(cursor bool) []Rendering {
+	steps := rendering.revealSteps()
+	width, offset := rendering.Width, 0
+	rightToLeft := rendering.spansRightToLeft()
+	if cursor {
+		width, offset = rendering.Width+1, cursorOffset(rightToLeft)
+	}
+
+	revealed := make([][]bool, len(rendering.Rows))
+	for row := range revealed {
+		revealed[row] = make([]bool, rendering.Width)
+	}
+
+	var cursorArea image.Rectangle
+	if cursor && len(steps) > 0 {
+		first := steps[0].bounds()
+		col := first.Min.X
+		if rightToLeft {
+			col = first.Max.X - 1 + offset
+		}
+		cursorArea = image.Rect(col, first.Min.Y, col+1, first.Max.Y)
+	}
+	frames := make([]Rendering, 0, typewriterFrameCount(len(steps), cursor))
+	frames = append(frames, rendering.frame(revealed, width, offset, cursorArea))
+	for _, step := range steps {
+		for _, area := range step.areas {
+			for row := area.Min.Y; row < area.Max.Y; row++ {
+				for col := area.Min.X; col < area.Max.X; col++ {
+					revealed[row][col] = true
+				}
+			}
+		}
+		if cursor {
+			cursorArea = cursorAfter(step.bounds(), offset, rightToLeft)
+		}
+		frames = append(frames, rendering.frame(revealed, width, offset, cursorArea))
+	}
+
+	if cursor {
+		for blink := 0; blink < cursorBlinks; blink++ {
+			frames = append(frames, rendering.frame(revealed, width, offset, image.Rectangle{}))
+			frames = append(frames, rendering.frame(revealed, width, offset, cursorArea))
+		}
+	}
+
+	return frames
+}
+
+// TypewriterSize returns count of frames of Typewriter and size of their
+// images without generating frames
+func (rendering Rendering) TypewriterSize(cursor bool, opts ImageOptions) (int, image.Point) {
+	width := rendering.Width
+	if cursor {
+		width++
+	}
+
+	return typewriterFrameCount(len(rendering.revealSteps()), cursor), rendering.frameImageSize(width, opts)
+}
+
+// BlinkingCursor returns two frames of rendering with and without cursor
+// after the last character in print direction. Cursor takes extra column
+// of frames
+func (rendering Rendering) BlinkingCursor() []Rendering {
+	steps := rendering.revealSteps()
+	rightToLeft := rendering.spansRightToLeft()
+	width, offset := rendering.Width+1, cursorOffset(rightToLeft)
+	revealed := make([][]bool, len(rendering.Rows))
+	for row := range revealed {
+		revealed[row] = make([]bool, rendering.Width)
+		for col := range revealed[row] {
+			revealed[row][col] = true
+		}
+	}
+
+	var cursorArea image.Rectangle
+	if len(steps) > 0 {
+		cursorArea = cursorAfter(steps[len(steps)-1].bounds(), offset, rightToLeft)
+	}
+
+	return []Rendering{
+		rendering.frame(revealed, width, offset, cursorArea),
+		rendering.frame(revealed, width, offset, image.Rectangle{}),
+	}
+}
+
+// BlinkingCursorSize returns count of frames of BlinkingCursor and size of
+// their images without generating frames
+func (rendering Rendering) BlinkingCursorSize(opts ImageOptions) (int, image.Point) {
+	return 2, rendering.frameImageSize(rendering.Width+1, opts)
+}
+
+// Marquee returns frames scrolling rendering from right to left through
+// window of width columns, rendering width is used if width isn't
+// positive. The first frame is blank and the last one shows the last
+// column of rendering at the left edge
+func (rendering Rendering) Marquee(width int) []Rendering {
+	count, width := rendering.marqueeFrames(width)
+
+	cells, colors := rendering.grid()
+	frames := make([]Rendering, 0, count)
+	for offset := -width; offset < rendering.Width; offset++ {
+		frameCells := make([][]rune, len(cells))
+		var frameColors [][]color.RGBA
+		if colors != nil {
+			frameColors = make([][]color.RGBA, len(colors))
+		}
+		for row := range cells {
+			frameCells[row] = make([]rune, width)
+			if colors != nil {
+				frameColors[row] = make([]color.RGBA, width)
+			}
+			for col := range frameCells[row] {
+				frameCells[row][col] = ' '
+				if src := offset + col; src >= 0 && src < rendering.Width {
+					frameCells[row][col] = cells[row][src]
+					if colors != nil {
+						frameColors[row][col] = colors[row][src]
+					}
+				}
+			}
+		}
+		frames = append(frames, rendering.withGrid(frameCells, frameColors, nil))
+	}
+
+	return frames
+}
+
+// MarqueeSize returns count of frames of Marquee and size of their images
+// without generating frames
+func (rendering Rendering) MarqueeSize(width int, opts ImageOptions) (int, image.Point) {
+	count, width := rendering.marqueeFrames(width)
+
+	return count, rendering.frameImageSize(width, opts)
+}
+
+// AnimatedGIF writes frames drawn by built-in bitmap font as animated GIF.
+// Image has size of the largest frame
+func AnimatedGIF(w io.Writer, frames []Rendering, opts ImageOptions, animOpts AnimationOptions) error {
+	if animOpts.Delay <= 0 {
+		animOpts.Delay = defaultFrameDelay
+	}
+
+	animation := gif.GIF{LoopCount: animOpts.LoopCount}
+	for _, frame := range frames {
+		img := palettedImage(frame.Image(opts))
+		animation.Image = append(animation.Image, img)
+		animation.Delay = append(animation.Delay, animOpts.Delay)
+		// transparent background mustn't show previous frame
+		animation.Disposal = append(animation.Disposal, gif.DisposalBackground)
+
+		size := img.Bounds().Max
+		if size.X > animation.Config.Width {
+			animation.Config.Width = size.X
+		}
+		if size.Y > animation.Config.Height {
+			animation.Config.Height = size.Y
+		}
+	}
+
+	return gif.EncodeAll(w, &animation)
+}
+
+// revealSteps returns areas of phrase characters in order of phrase.
+// Rows of character are rows of its FIG line up to the top of the next one
+func (rendering Rendering) revealSteps() []revealStep {
+	if len(rendering.Spans) == 0 {
+		return rendering.columnSteps()
+	}
+
+	lineTops := make(map[int]int)
+	for _, span := range rendering.Spans {
+		lineTops[span.Line] = span.Row
+	}
+	lineBottoms := make(map[int]int)
+	for line, top := range lineTops {
+		bottom := len(rendering.Rows)
+		for _, otherTop := range lineTops {
+			if otherTop > top && otherTop < bottom {
+				bottom = otherTop
+			}
+		}
+		lineBottoms[line] = bottom
+	}
+
+	spans := make([]GlyphSpan, len(rendering.Spans))
+	copy(spans, rendering.Spans)
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].Index < spans[j].Index })
+
+	var steps []revealStep
+	for idx, span := range spans {
+		area := image.Rect(span.Start, span.Row, span.End, lineBottoms[span.Line])
+		area = area.Intersect(image.Rect(0, 0, rendering.Width, len(rendering.Rows)))
+		if area.Empty() {
+			continue
+		}
+		if idx > 0 && len(steps) > 0 && spans[idx-1].Index == span.Index {
+			steps[len(steps)-1].areas = append(steps[len(steps)-1].areas, area)
+		} else {
+			steps = append(steps, revealStep{areas: []image.Rectangle{area}})
+		}
+	}
+
+	return steps
+}
+
+// columnSteps returns non-blank columns of rendering
+func (rendering Rendering) columnSteps() []revealStep {
+	cells, _ := rendering.grid()
+	var steps []revealStep
+	for col := 0; col < rendering.Width; col++ {
+		for row := range cells {
+			if cells[row][col] != ' ' {
+				steps = append(steps, revealStep{areas: []image.Rectangle{image.Rect(col, 0, col+1, len(cells))}})
+				break
+			}
+		}
+	}
+
+	return steps
+}
+
+// bounds returns area covering all areas of step
+func (step revealStep) bounds() image.Rectangle {
+	var bounds image.Rectangle
+	for _, area := range step.areas {
+		bounds = bounds.Union(area)
+	}
+
+	return bounds
+}
+
+// spansRightToLeft reports whether characters of phrase are placed from
+// right to left, that is a later character of FIG line starts on the left
+// of the previous one
+func (rendering Rendering) spansRightToLeft() bool {
+	for idx := 1; idx < len(rendering.Spans); idx++ {
+		prev, span := rendering.Spans[idx-1], rendering.Spans[idx]
+		if prev.Line == span.Line && prev.Index != span.Index {
+			return span.Start < prev.Start
+		}
+	}
+
+	return false
+}
+
+// cursorOffset returns offset of rendering in frames with extra column
+// for cursor, the column is on the left if characters are placed from
+// right to left
+func cursorOffset(rightToLeft bool) int {
+	if rightToLeft {
+		return 1
+	}
+
+	return 0
+}
+
+// cursorAfter returns cursor area of frame placed after bounds of revealed
+// character in print direction. Bounds are shifted by offset of rendering
+func cursorAfter(bounds image.Rectangle, offset int, rightToLeft bool) image.Rectangle {
+	col := bounds.Max.X + offset
+	if rightToLeft {
+		col = bounds.Min.X - 1 + offset
+	}
+
+	return image.Rect(col, bounds.Min.Y, col+1, bounds.Max.Y)
+}
+
+// typewriterFrameCount returns count of Typewriter frames revealing steps
+func typewriterFrameCount(steps int, cursor bool) int {
+	count := steps + 1
+	if cursor {
+		count += 2 * cursorBlinks
+	}
+
+	return count
+}
+
+// marqueeFrames returns count and width of Marquee frames scrolling
+// through window of width columns
+func (rendering Rendering) marqueeFrames(width int) (int, int) {
+	if width <= 0 {
+		width = rendering.Width
+	}
+
+	return rendering.Width + width, width
+}
+
+// frameImageSize returns size of image of frame of rendering rows which
+// has width columns
+func (rendering Rendering) frameImageSize(width int, opts ImageOptions) image.Point {
+	return Rendering{Rows: rendering.Rows, Width: width}.ImageSize(opts)
+}
+
+// frame returns rendering showing only revealed cells with cursor drawn
+// in cursor area. Frame has width columns and rendering is shifted right
+// by offset columns in it
+func (rendering Rendering) frame(revealed [][]bool, width, offset int, cursorArea image.Rectangle) Rendering {
+	cells, colors := rendering.grid()
+	frameCells := make([][]rune, len(cells))
+	var frameColors [][]color.RGBA
+	if colors != nil {
+		frameColors = make([][]color.RGBA, len(colors))
+	}
+
+	for row := range cells {
+		frameCells[row] = make([]rune, width)
+		if colors != nil {
+			frameColors[row] = make([]color.RGBA, width)
+		}
+		for col := range frameCells[row] {
+			frameCells[row][col] = ' '
+			src := col - offset
+			switch {
+			case image.Pt(col, row).In(cursorArea):
+				frameCells[row][col] = cursorChar
+			case src >= 0 && src < rendering.Width && revealed[row][src]:
+				frameCells[row][col] = cells[row][src]
+				if colors != nil {
+					frameColors[row][col] = colors[row][src]
+				}
+			}
+		}
+	}
+
+	return rendering.withGrid(frameCells, frameColors, nil)
+}
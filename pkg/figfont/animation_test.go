@@ -0,0 +1,233 @@
+package figfont
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"reflect"
+	"testing"
+)
+
+func framesRows(frames []Rendering) [][]string {
+	rows := make([][]string, len(frames))
+	for idx, frame := range frames {
+		rows[idx] = frame.Rows
+	}
+
+	return rows
+}
+
+func TestRenderingTypewriter(t *testing.T) {
+	font := testFont(Layout{})
+	rendering, err := font.Render("LI", PrintOptions{})
+	assertNoError(t, err)
+
+	testCases := []struct {
+		name     string
+		cursor   bool
+		expected [][]string
+	}{
+		{
+			"without cursor",
+			false,
+			[][]string{
+				{"     ", "     ", "     "},
+				{"|    ", "|    ", "|__  "},
+				{"|   |", "|   |", "|__ |"},
+			},
+		},
+		{
+			"with cursor",
+			true,
+			[][]string{
+				{"█     ", "█     ", "█     "},
+				{"|  █  ", "|  █  ", "|__█  "},
+				{"|   |█", "|   |█", "|__ |█"},
+				{"|   | ", "|   | ", "|__ | "},
+				{"|   |█", "|   |█", "|__ |█"},
+				{"|   | ", "|   | ", "|__ | "},
+				{"|   |█", "|   |█", "|__ |█"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			frames := rendering.Typewriter(testCase.cursor)
+			if rows := framesRows(frames); !reflect.DeepEqual(testCase.expected, rows) {
+				t.Errorf("want %q but got %q", testCase.expected, rows)
+			}
+		})
+	}
+}
+
+func TestRenderingTypewriterRightToLeft(t *testing.T) {
+	font := testFont(Layout{})
+	rendering, err := font.Render("LI", PrintOptions{Direction: RightToLeft})
+	assertNoError(t, err)
+
+	frames := rendering.Typewriter(true)
+	expected := [][]string{
+		{"     █", "     █", "     █"},
+		{"  █|  ", "  █|  ", "  █|__"},
+		{"█ ||  ", "█ ||  ", "█ ||__"},
+		{"  ||  ", "  ||  ", "  ||__"},
+		{"█ ||  ", "█ ||  ", "█ ||__"},
+		{"  ||  ", "  ||  ", "  ||__"},
+		{"█ ||  ", "█ ||  ", "█ ||__"},
+	}
+	if rows := framesRows(frames); !reflect.DeepEqual(expected, rows) {
+		t.Errorf("want %q but got %q", expected, rows)
+	}
+}
+
+func TestRenderingTypewriterLines(t *testing.T) {
+	font := testFont(Layout{})
+	rendering, err := font.Render("L\nI", PrintOptions{})
+	assertNoError(t, err)
+
+	frames := rendering.Typewriter(false)
+	expected := [][]string{
+		{"   ", "   ", "   ", "   ", "   ", "   "},
+		{"|  ", "|  ", "|__", "   ", "   ", "   "},
+		{"|  ", "|  ", "|__", " | ", " | ", " | "},
+	}
+	if rows := framesRows(frames); !reflect.DeepEqual(expected, rows) {
+		t.Errorf("want %q but got %q", expected, rows)
+	}
+}
+
+func TestRenderingTypewriterWithoutSpans(t *testing.T) {
+	rendering := Rendering{Rows: []string{"a b", "c"}, Width: 3, Height: 2}
+
+	frames := rendering.Typewriter(false)
+	expected := [][]string{{"   ", "   "}, {"a  ", "c  "}, {"a b", "c  "}}
+	if rows := framesRows(frames); !reflect.DeepEqual(expected, rows) {
+		t.Errorf("want %q but got %q", expected, rows)
+	}
+}
+
+func TestRenderingBlinkingCursor(t *testing.T) {
+	font := testFont(Layout{})
+	rendering, err := font.Render("I", PrintOptions{})
+	assertNoError(t, err)
+
+	frames := rendering.BlinkingCursor()
+	expected := [][]string{{" |█", " |█", " |█"}, {" | ", " | ", " | "}}
+	if rows := framesRows(frames); !reflect.DeepEqual(expected, rows) {
+		t.Errorf("want %q but got %q", expected, rows)
+	}
+}
+
+func TestRenderingBlinkingCursorRightToLeft(t *testing.T) {
+	font := testFont(Layout{})
+	rendering, err := font.Render("LI", PrintOptions{Direction: RightToLeft})
+	assertNoError(t, err)
+
+	frames := rendering.BlinkingCursor()
+	expected := [][]string{{"█ ||  ", "█ ||  ", "█ ||__"}, {"  ||  ", "  ||  ", "  ||__"}}
+	if rows := framesRows(frames); !reflect.DeepEqual(expected, rows) {
+		t.Errorf("want %q but got %q", expected, rows)
+	}
+}
+
+func TestRenderingMarquee(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	rendering := Rendering{Rows: []string{"ab"}, Width: 2, Height: 1}.Apply(Gradient(red, red))
+
+	testCases := []struct {
+		name     string
+		width    int
+		expected [][]string
+	}{
+		{"rendering width", 0, [][]string{{"  "}, {" a"}, {"ab"}, {"b "}}},
+		{"wide window", 3, [][]string{{"   "}, {"  a"}, {" ab"}, {"ab "}, {"b  "}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			frames := rendering.Marquee(testCase.width)
+			if rows := framesRows(frames); !reflect.DeepEqual(testCase.expected, rows) {
+				t.Errorf("want %q but got %q", testCase.expected, rows)
+			}
+			expectedColors := []color.RGBA{{}, red}
+			if colors := frames[1].Colors[0]; !reflect.DeepEqual(expectedColors, colors[len(colors)-2:]) {
+				t.Errorf("want colors %v but got %v", expectedColors, colors)
+			}
+		})
+	}
+}
+
+func TestRenderingAnimationSize(t *testing.T) {
+	font := testFont(Layout{})
+	rendering, err := font.Render("LI\nI", PrintOptions{})
+	assertNoError(t, err)
+	opts := ImageOptions{Scale: 2, Padding: 1}
+
+	testCases := []struct {
+		name   string
+		frames []Rendering
+		size   func() (int, image.Point)
+	}{
+		{
+			"typewriter",
+			rendering.Typewriter(false),
+			func() (int, image.Point) { return rendering.TypewriterSize(false, opts) },
+		},
+		{
+			"typewriter with cursor",
+			rendering.Typewriter(true),
+			func() (int, image.Point) { return rendering.TypewriterSize(true, opts) },
+		},
+		{
+			"blinking cursor",
+			rendering.BlinkingCursor(),
+			func() (int, image.Point) { return rendering.BlinkingCursorSize(opts) },
+		},
+		{
+			"marquee",
+			rendering.Marquee(3),
+			func() (int, image.Point) { return rendering.MarqueeSize(3, opts) },
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			count, size := testCase.size()
+			assertIntEqual(t, len(testCase.frames), count)
+			for _, frame := range testCase.frames {
+				if frameSize := frame.ImageSize(opts); frameSize != size {
+					t.Errorf("want frame size %v but got %v", size, frameSize)
+				}
+			}
+		})
+	}
+}
+
+func TestAnimatedGIF(t *testing.T) {
+	frames := []Rendering{
+		{Rows: []string{"a"}, Width: 1, Height: 1},
+		{Rows: []string{"ab", "c"}, Width: 2, Height: 2},
+	}
+
+	var buf bytes.Buffer
+	err := AnimatedGIF(&buf, frames, ImageOptions{Scale: 2}, AnimationOptions{Delay: 50, LoopCount: -1})
+	assertNoError(t, err)
+
+	animation, err := gif.DecodeAll(&buf)
+	assertNoError(t, err)
+	assertIntEqual(t, 2, len(animation.Image))
+	assertIntEqual(t, 2*2*bitmapCellWidth, animation.Config.Width)
+	assertIntEqual(t, 2*2*bitmapCellHeight, animation.Config.Height)
+	if !reflect.DeepEqual([]int{50, 50}, animation.Delay) {
+		t.Errorf("unexpected delays %v", animation.Delay)
+	}
+	assertIntEqual(t, -1, animation.LoopCount)
+}
+
+func TestAnimatedGIFWithoutFrames(t *testing.T) {
+	var buf bytes.Buffer
+	err := AnimatedGIF(&buf, nil, ImageOptions{}, AnimationOptions{})
+	assertError(t, err, "gif: must provide at least one image")
+}
@@ -0,0 +1,291 @@
+package figfont
+
+const (
+	// bitmapGlyphWidth and bitmapGlyphHeight are sizes of glyphs of
+	// built-in bitmap font, the last row is used by descenders
+	bitmapGlyphWidth  = 5
+	bitmapGlyphHeight = 8
+	// bitmapCellWidth and bitmapCellHeight are sizes of sub-character
+	// cell of raster image including spacing
+	bitmapCellWidth  = bitmapGlyphWidth + 1
+	bitmapCellHeight = bitmapGlyphHeight + 1
+)
+
+// bitmapUnknown is drawn for sub-characters absent in bitmap font
+var bitmapUnknown = [bitmapGlyphHeight]string{
+	"#####", "#...#", "#...#", "#...#", "#...#", "#...#", "#####", ".....",
+}
+
+// bitmapGlyphs is built-in monospace bitmap font covering printable ASCII
+// characters, box drawing characters and block elements used by TOIlet
+// fonts. Pixels are drawn by '#'
+var bitmapGlyphs = map[rune][bitmapGlyphHeight]string{
+	' ':  {".....", ".....", ".....", ".....", ".....", ".....", ".....", "....."},
+	'!':  {"..#..", "..#..", "..#..", "..#..", "..#..", ".....", "..#..", "....."},
+	'"':  {".#.#.", ".#.#.", ".#.#.", ".....", ".....", ".....", ".....", "....."},
+	'#':  {".#.#.", ".#.#.", "#####", ".#.#.", "#####", ".#.#.", ".#.#.", "....."},
+	'$':  {"..#..", ".####", "#.#..", ".###.", "..#.#", "####.", "..#..", "....."},
+	'%':  {"##...", "##..#", "...#.", "..#..", ".#...", "#..##", "...##", "....."},
+	'&':  {".##..", "#..#.", "#.#..", ".#...", "#.#.#", "#..#.", ".##.#", "....."},
+	'\'': {"..#..", "..#..", ".#...", ".....", ".....", ".....", ".....", "....."},
+	'(':  {"...#.", "..#..", ".#...", ".#...", ".#...", "..#..", "...#.", "....."},
+	')':  {".#...", "..#..", "...#.", "...#.", "...#.", "..#..", ".#...", "....."},
+	'*':  {".....", "..#..", "#.#.#", ".###.", "#.#.#", "..#..", ".....", "....."},
+	'+':  {".....", "..#..", "..#..", "#####", "..#..", "..#..", ".....", "....."},
+	',':  {".....", ".....", ".....", ".....", ".....", "..#..", "..#..", ".#..."},
+	'-':  {".....", ".....", ".....", "#####", ".....", ".....", ".....", "....."},
+	'.':  {".....", ".....", ".....", ".....", ".....", ".##..", ".##..", "....."},
+	'/':  {".....", "....#", "...#.", "..#..", ".#...", "#....", ".....", "....."},
+	'0':  {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###.", "....."},
+	'1':  {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###.", "....."},
+	'2':  {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####", "....."},
+	'3':  {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###.", "....."},
+	'4':  {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#.", "....."},
+	'5':  {"#####", "#....", "####.", "....#", "....#", "#...#", ".###.", "....."},
+	'6':  {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###.", "....."},
+	'7':  {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#...", "....."},
+	'8':  {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###.", "....."},
+	'9':  {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##..", "....."},
+	':':  {".....", ".##..", ".##..", ".....", ".##..", ".##..", ".....", "....."},
+	';':  {".....", ".##..", ".##..", ".....", ".##..", "..#..", ".#...", "....."},
+	'<':  {"...#.", "..#..", ".#...", "#....", ".#...", "..#..", "...#.", "....."},
+	'=':  {".....", ".....", "#####", ".....", "#####", ".....", ".....", "....."},
+	'>':  {".#...", "..#..", "...#.", "....#", "...#.", "..#..", ".#...", "....."},
+	'?':  {".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#..", "....."},
+	'@':  {".###.", "#...#", "....#", ".##.#", "#.#.#", "#.#.#", ".###.", "....."},
+	'A':  {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#", "....."},
+	'B':  {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####.", "....."},
+	'C':  {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###.", "....."},
+	'D':  {"###..", "#..#.", "#...#", "#...#", "#...#", "#..#.", "###..", "....."},
+	'E':  {"#####", "#....", "#....", "####.", "#....", "#....", "#####", "....."},
+	'F':  {"#####", "#....", "#....", "####.", "#....", "#....", "#....", "....."},
+	'G':  {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####", "....."},
+	'H':  {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#", "....."},
+	'I':  {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###.", "....."},
+	'J':  {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##..", "....."},
+	'K':  {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#", "....."},
+	'L':  {"#....", "#....", "#....", "#....", "#....", "#....", "#####", "....."},
+	'M':  {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#", "....."},
+	'N':  {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#", "....."},
+	'O':  {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###.", "....."},
+	'P':  {"####.", "#...#", "#...#", "####.", "#....", "#....", "#....", "....."},
+	'Q':  {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#", "....."},
+	'R':  {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#", "....."},
+	'S':  {".####", "#....", "#....", ".###.", "....#", "....#", "####.", "....."},
+	'T':  {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#..", "....."},
+	'U':  {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###.", "....."},
+	'V':  {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#..", "....."},
+	'W':  {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#.", "....."},
+	'X':  {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#", "....."},
+	'Y':  {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#..", "....."},
+	'Z':  {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####", "....."},
+	'[':  {".###.", ".#...", ".#...", ".#...", ".#...", ".#...", ".###.", "....."},
+	'\\': {".....", "#....", ".#...", "..#..", "...#.", "....#", ".....", "....."},
+	']':  {".###.", "...#.", "...#.", "...#.", "...#.", "...#.", ".###.", "....."},
+	'^':  {"..#..", ".#.#.", "#...#", ".....", ".....", ".....", ".....", "....."},
+	'_':  {".....", ".....", ".....", ".....", ".....", ".....", ".....", "#####"},
+	'`':  {".#...", "..#..", "...#.", ".....", ".....", ".....", ".....", "....."},
+	'a':  {".....", ".....", ".###.", "....#", ".####", "#...#", ".####", "....."},
+	'b':  {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "####.", "....."},
+	'c':  {".....", ".....", ".###.", "#....", "#....", "#...#", ".###.", "....."},
+	'd':  {"....#", "....#", ".##.#", "#..##", "#...#", "#...#", ".####", "....."},
+	'e':  {".....", ".....", ".###.", "#...#", "#####", "#....", ".###.", "....."},
+	'f':  {"..##.", ".#..#", ".#...", "###..", ".#...", ".#...", ".#...", "....."},
+	'g':  {".....", ".....", ".####", "#...#", "#...#", ".####", "....#", ".###."},
+	'h':  {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "#...#", "....."},
+	'i':  {"..#..", ".....", ".##..", "..#..", "..#..", "..#..", ".###.", "....."},
+	'j':  {"...#.", ".....", "..##.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'k':  {"#....", "#....", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "....."},
+	'l':  {".##..", "..#..", "..#..", "..#..", "..#..", "..#..", ".###.", "....."},
+	'm':  {".....", ".....", "##.#.", "#.#.#", "#.#.#", "#...#", "#...#", "....."},
+	'n':  {".....", ".....", "#.##.", "##..#", "#...#", "#...#", "#...#", "....."},
+	'o':  {".....", ".....", ".###.", "#...#", "#...#", "#...#", ".###.", "....."},
+	'p':  {".....", ".....", "####.", "#...#", "#...#", "####.", "#....", "#...."},
+	'q':  {".....", ".....", ".####", "#...#", "#...#", ".####", "....#", "....#"},
+	'r':  {".....", ".....", "#.##.", "##..#", "#....", "#....", "#....", "....."},
+	's':  {".....", ".....", ".###.", "#....", ".###.", "....#", "####.", "....."},
+	't':  {".#...", ".#...", "###..", ".#...", ".#...", ".#..#", "..##.", "....."},
+	'u':  {".....", ".....", "#...#", "#...#", "#...#", "#..##", ".##.#", "....."},
+	'v':  {".....", ".....", "#...#", "#...#", "#...#", ".#.#.", "..#..", "....."},
+	'w':  {".....", ".....", "#...#", "#...#", "#.#.#", "#.#.#", ".#.#.", "....."},
+	'x':  {".....", ".....", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "....."},
+	'y':  {".....", ".....", "#...#", "#...#", "#...#", ".####", "....#", ".###."},
+	'z':  {".....", ".....", "#####", "...#.", "..#..", ".#...", "#####", "....."},
+	'{':  {"...#.", "..#..", "..#..", ".#...", "..#..", "..#..", "...#.", "....."},
+	'|':  {"..#..", "..#..", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'}':  {".#...", "..#..", "..#..", "...#.", "..#..", "..#..", ".#...", "....."},
+	'~':  {".....", ".....", ".#...", "#.#.#", "...#.", ".....", ".....", "....."},
+	'‾':  {"#####", ".....", ".....", ".....", ".....", ".....", ".....", "....."},
+	// box drawing characters
+	'─': {".....", ".....", ".....", "#####", ".....", ".....", ".....", "....."},
+	'━': {".....", ".....", ".....", "#####", "#####", ".....", ".....", "....."},
+	'│': {"..#..", "..#..", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'┃': {"..##.", "..##.", "..##.", "..##.", "..##.", "..##.", "..##.", "..##."},
+	'┄': {".....", ".....", ".....", "#.#.#", ".....", ".....", ".....", "....."},
+	'┅': {".....", ".....", ".....", "#.#.#", "#.#.#", ".....", ".....", "....."},
+	'┆': {"..#..", "..#..", ".....", "..#..", "..#..", ".....", "..#..", "....."},
+	'┇': {"..##.", "..##.", ".....", "..##.", "..##.", ".....", "..##.", "....."},
+	'┈': {".....", ".....", ".....", "#.#.#", ".....", ".....", ".....", "....."},
+	'┉': {".....", ".....", ".....", "#.#.#", "#.#.#", ".....", ".....", "....."},
+	'┊': {"..#..", ".....", "..#..", ".....", "..#..", ".....", "..#..", "....."},
+	'┋': {"..##.", ".....", "..##.", ".....", "..##.", ".....", "..##.", "....."},
+	'┌': {".....", ".....", ".....", "..###", "..#..", "..#..", "..#..", "..#.."},
+	'┍': {".....", ".....", ".....", "..###", "..###", "..#..", "..#..", "..#.."},
+	'┎': {".....", ".....", ".....", "..###", "..##.", "..##.", "..##.", "..##."},
+	'┏': {".....", ".....", ".....", "..###", "..###", "..##.", "..##.", "..##."},
+	'┐': {".....", ".....", ".....", "###..", "..#..", "..#..", "..#..", "..#.."},
+	'┑': {".....", ".....", ".....", "###..", "###..", "..#..", "..#..", "..#.."},
+	'┒': {".....", ".....", ".....", "####.", "..##.", "..##.", "..##.", "..##."},
+	'┓': {".....", ".....", ".....", "####.", "####.", "..##.", "..##.", "..##."},
+	'└': {"..#..", "..#..", "..#..", "..###", ".....", ".....", ".....", "....."},
+	'┕': {"..#..", "..#..", "..#..", "..###", "..###", ".....", ".....", "....."},
+	'┖': {"..##.", "..##.", "..##.", "..###", ".....", ".....", ".....", "....."},
+	'┗': {"..##.", "..##.", "..##.", "..###", "..###", ".....", ".....", "....."},
+	'┘': {"..#..", "..#..", "..#..", "###..", ".....", ".....", ".....", "....."},
+	'┙': {"..#..", "..#..", "..#..", "###..", "###..", ".....", ".....", "....."},
+	'┚': {"..##.", "..##.", "..##.", "####.", ".....", ".....", ".....", "....."},
+	'┛': {"..##.", "..##.", "..##.", "####.", "####.", ".....", ".....", "....."},
+	'├': {"..#..", "..#..", "..#..", "..###", "..#..", "..#..", "..#..", "..#.."},
+	'┝': {"..#..", "..#..", "..#..", "..###", "..###", "..#..", "..#..", "..#.."},
+	'┞': {"..##.", "..##.", "..##.", "..###", "..#..", "..#..", "..#..", "..#.."},
+	'┟': {"..#..", "..#..", "..#..", "..###", "..##.", "..##.", "..##.", "..##."},
+	'┠': {"..##.", "..##.", "..##.", "..###", "..##.", "..##.", "..##.", "..##."},
+	'┡': {"..##.", "..##.", "..##.", "..###", "..###", "..#..", "..#..", "..#.."},
+	'┢': {"..#..", "..#..", "..#..", "..###", "..###", "..##.", "..##.", "..##."},
+	'┣': {"..##.", "..##.", "..##.", "..###", "..###", "..##.", "..##.", "..##."},
+	'┤': {"..#..", "..#..", "..#..", "###..", "..#..", "..#..", "..#..", "..#.."},
+	'┥': {"..#..", "..#..", "..#..", "###..", "###..", "..#..", "..#..", "..#.."},
+	'┦': {"..##.", "..##.", "..##.", "####.", "..#..", "..#..", "..#..", "..#.."},
+	'┧': {"..#..", "..#..", "..#..", "####.", "..##.", "..##.", "..##.", "..##."},
+	'┨': {"..##.", "..##.", "..##.", "####.", "..##.", "..##.", "..##.", "..##."},
+	'┩': {"..##.", "..##.", "..##.", "####.", "####.", "..#..", "..#..", "..#.."},
+	'┪': {"..#..", "..#..", "..#..", "####.", "####.", "..##.", "..##.", "..##."},
+	'┫': {"..##.", "..##.", "..##.", "####.", "####.", "..##.", "..##.", "..##."},
+	'┬': {".....", ".....", ".....", "#####", "..#..", "..#..", "..#..", "..#.."},
+	'┭': {".....", ".....", ".....", "#####", "###..", "..#..", "..#..", "..#.."},
+	'┮': {".....", ".....", ".....", "#####", "..###", "..#..", "..#..", "..#.."},
+	'┯': {".....", ".....", ".....", "#####", "#####", "..#..", "..#..", "..#.."},
+	'┰': {".....", ".....", ".....", "#####", "..##.", "..##.", "..##.", "..##."},
+	'┱': {".....", ".....", ".....", "#####", "####.", "..##.", "..##.", "..##."},
+	'┲': {".....", ".....", ".....", "#####", "..###", "..##.", "..##.", "..##."},
+	'┳': {".....", ".....", ".....", "#####", "#####", "..##.", "..##.", "..##."},
+	'┴': {"..#..", "..#..", "..#..", "#####", ".....", ".....", ".....", "....."},
+	'┵': {"..#..", "..#..", "..#..", "#####", "###..", ".....", ".....", "....."},
+	'┶': {"..#..", "..#..", "..#..", "#####", "..###", ".....", ".....", "....."},
+	'┷': {"..#..", "..#..", "..#..", "#####", "#####", ".....", ".....", "....."},
+	'┸': {"..##.", "..##.", "..##.", "#####", ".....", ".....", ".....", "....."},
+	'┹': {"..##.", "..##.", "..##.", "#####", "####.", ".....", ".....", "....."},
+	'┺': {"..##.", "..##.", "..##.", "#####", "..###", ".....", ".....", "....."},
+	'┻': {"..##.", "..##.", "..##.", "#####", "#####", ".....", ".....", "....."},
+	'┼': {"..#..", "..#..", "..#..", "#####", "..#..", "..#..", "..#..", "..#.."},
+	'┽': {"..#..", "..#..", "..#..", "#####", "###..", "..#..", "..#..", "..#.."},
+	'┾': {"..#..", "..#..", "..#..", "#####", "..###", "..#..", "..#..", "..#.."},
+	'┿': {"..#..", "..#..", "..#..", "#####", "#####", "..#..", "..#..", "..#.."},
+	'╀': {"..##.", "..##.", "..##.", "#####", "..#..", "..#..", "..#..", "..#.."},
+	'╁': {"..#..", "..#..", "..#..", "#####", "..##.", "..##.", "..##.", "..##."},
+	'╂': {"..##.", "..##.", "..##.", "#####", "..##.", "..##.", "..##.", "..##."},
+	'╃': {"..##.", "..##.", "..##.", "#####", "####.", "..#..", "..#..", "..#.."},
+	'╄': {"..##.", "..##.", "..##.", "#####", "..###", "..#..", "..#..", "..#.."},
+	'╅': {"..#..", "..#..", "..#..", "#####", "####.", "..##.", "..##.", "..##."},
+	'╆': {"..#..", "..#..", "..#..", "#####", "..###", "..##.", "..##.", "..##."},
+	'╇': {"..##.", "..##.", "..##.", "#####", "#####", "..#..", "..#..", "..#.."},
+	'╈': {"..#..", "..#..", "..#..", "#####", "#####", "..##.", "..##.", "..##."},
+	'╉': {"..##.", "..##.", "..##.", "#####", "####.", "..##.", "..##.", "..##."},
+	'╊': {"..##.", "..##.", "..##.", "#####", "..###", "..##.", "..##.", "..##."},
+	'╋': {"..##.", "..##.", "..##.", "#####", "#####", "..##.", "..##.", "..##."},
+	'╌': {".....", ".....", ".....", "##.##", ".....", ".....", ".....", "....."},
+	'╍': {".....", ".....", ".....", "##.##", "##.##", ".....", ".....", "....."},
+	'╎': {"..#..", "..#..", "..#..", ".....", "..#..", "..#..", "..#..", "....."},
+	'╏': {"..##.", "..##.", "..##.", ".....", "..##.", "..##.", "..##.", "....."},
+	'═': {".....", ".....", "#####", ".....", "#####", ".....", ".....", "....."},
+	'║': {".#.#.", ".#.#.", ".#.#.", ".#.#.", ".#.#.", ".#.#.", ".#.#.", ".#.#."},
+	'╒': {".....", ".....", "..###", "..#..", "..###", "..#..", "..#..", "..#.."},
+	'╓': {".....", ".....", ".....", ".####", ".#.#.", ".#.#.", ".#.#.", ".#.#."},
+	'╔': {".....", ".....", ".####", ".#...", ".#.##", ".#.#.", ".#.#.", ".#.#."},
+	'╕': {".....", ".....", "###..", "..#..", "###..", "..#..", "..#..", "..#.."},
+	'╖': {".....", ".....", ".....", "####.", ".#.#.", ".#.#.", ".#.#.", ".#.#."},
+	'╗': {".....", ".....", "####.", "...#.", "##.#.", ".#.#.", ".#.#.", ".#.#."},
+	'╘': {"..#..", "..#..", "..###", "..#..", "..###", ".....", ".....", "....."},
+	'╙': {".#.#.", ".#.#.", ".#.#.", ".####", ".....", ".....", ".....", "....."},
+	'╚': {".#.#.", ".#.#.", ".#.##", ".#...", ".####", ".....", ".....", "....."},
+	'╛': {"..#..", "..#..", "###..", "..#..", "###..", ".....", ".....", "....."},
+	'╜': {".#.#.", ".#.#.", ".#.#.", "####.", ".....", ".....", ".....", "....."},
+	'╝': {".#.#.", ".#.#.", "##.#.", "...#.", "####.", ".....", ".....", "....."},
+	'╞': {"..#..", "..#..", "..###", "..#..", "..###", "..#..", "..#..", "..#.."},
+	'╟': {".#.#.", ".#.#.", ".#.#.", ".####", ".#.#.", ".#.#.", ".#.#.", ".#.#."},
+	'╠': {".#.#.", ".#.#.", ".#.##", ".#...", ".#.##", ".#.#.", ".#.#.", ".#.#."},
+	'╡': {"..#..", "..#..", "###..", "..#..", "###..", "..#..", "..#..", "..#.."},
+	'╢': {".#.#.", ".#.#.", ".#.#.", "####.", ".#.#.", ".#.#.", ".#.#.", ".#.#."},
+	'╣': {".#.#.", ".#.#.", "##.#.", "...#.", "##.#.", ".#.#.", ".#.#.", ".#.#."},
+	'╤': {".....", ".....", "#####", "..#..", "#####", "..#..", "..#..", "..#.."},
+	'╥': {".....", ".....", ".....", "#####", ".#.#.", ".#.#.", ".#.#.", ".#.#."},
+	'╦': {".....", ".....", "#####", ".....", "##.##", ".#.#.", ".#.#.", ".#.#."},
+	'╧': {"..#..", "..#..", "#####", "..#..", "#####", ".....", ".....", "....."},
+	'╨': {".#.#.", ".#.#.", ".#.#.", "#####", ".....", ".....", ".....", "....."},
+	'╩': {".#.#.", ".#.#.", "##.##", ".....", "#####", ".....", ".....", "....."},
+	'╪': {"..#..", "..#..", "#####", "..#..", "#####", "..#..", "..#..", "..#.."},
+	'╫': {".#.#.", ".#.#.", ".#.#.", "#####", ".#.#.", ".#.#.", ".#.#.", ".#.#."},
+	'╬': {".#.#.", ".#.#.", "##.##", ".....", "##.##", ".#.#.", ".#.#.", ".#.#."},
+	'╭': {".....", ".....", ".....", "...##", "..#..", "..#..", "..#..", "..#.."},
+	'╮': {".....", ".....", ".....", "##...", "..#..", "..#..", "..#..", "..#.."},
+	'╯': {"..#..", "..#..", "..#..", "##...", ".....", ".....", ".....", "....."},
+	'╰': {"..#..", "..#..", "..#..", "...##", ".....", ".....", ".....", "....."},
+	'╱': {"....#", "...#.", "...#.", "..#..", "..#..", ".#...", ".#...", "#...."},
+	'╲': {"#....", ".#...", ".#...", "..#..", "..#..", "...#.", "...#.", "....#"},
+	'╳': {"#...#", ".#.#.", ".#.#.", "..#..", "..#..", ".#.#.", ".#.#.", "#...#"},
+	'╴': {".....", ".....", ".....", "###..", ".....", ".....", ".....", "....."},
+	'╵': {"..#..", "..#..", "..#..", "..#..", ".....", ".....", ".....", "....."},
+	'╶': {".....", ".....", ".....", "..###", ".....", ".....", ".....", "....."},
+	'╷': {".....", ".....", ".....", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'╸': {".....", ".....", ".....", "###..", "###..", ".....", ".....", "....."},
+	'╹': {"..##.", "..##.", "..##.", "..##.", ".....", ".....", ".....", "....."},
+	'╺': {".....", ".....", ".....", "..###", "..###", ".....", ".....", "....."},
+	'╻': {".....", ".....", ".....", "..##.", "..##.", "..##.", "..##.", "..##."},
+	'╼': {".....", ".....", ".....", "#####", "..###", ".....", ".....", "....."},
+	'╽': {"..#..", "..#..", "..#..", "..##.", "..##.", "..##.", "..##.", "..##."},
+	'╾': {".....", ".....", ".....", "#####", "###..", ".....", ".....", "....."},
+	'╿': {"..##.", "..##.", "..##.", "..##.", "..#..", "..#..", "..#..", "..#.."},
+
+	// block elements
+	'▀': {"#####", "#####", "#####", "#####", ".....", ".....", ".....", "....."},
+	'▁': {".....", ".....", ".....", ".....", ".....", ".....", ".....", "#####"},
+	'▂': {".....", ".....", ".....", ".....", ".....", ".....", "#####", "#####"},
+	'▃': {".....", ".....", ".....", ".....", ".....", "#####", "#####", "#####"},
+	'▄': {".....", ".....", ".....", ".....", "#####", "#####", "#####", "#####"},
+	'▅': {".....", ".....", ".....", "#####", "#####", "#####", "#####", "#####"},
+	'▆': {".....", ".....", "#####", "#####", "#####", "#####", "#####", "#####"},
+	'▇': {".....", "#####", "#####", "#####", "#####", "#####", "#####", "#####"},
+	'█': {"#####", "#####", "#####", "#####", "#####", "#####", "#####", "#####"},
+	'▉': {"####.", "####.", "####.", "####.", "####.", "####.", "####.", "####."},
+	'▊': {"####.", "####.", "####.", "####.", "####.", "####.", "####.", "####."},
+	'▋': {"###..", "###..", "###..", "###..", "###..", "###..", "###..", "###.."},
+	'▌': {"###..", "###..", "###..", "###..", "###..", "###..", "###..", "###.."},
+	'▍': {"##...", "##...", "##...", "##...", "##...", "##...", "##...", "##..."},
+	'▎': {"#....", "#....", "#....", "#....", "#....", "#....", "#....", "#...."},
+	'▏': {"#....", "#....", "#....", "#....", "#....", "#....", "#....", "#...."},
+	'▐': {"...##", "...##", "...##", "...##", "...##", "...##", "...##", "...##"},
+	'░': {"#.#.#", ".....", "#.#.#", ".....", "#.#.#", ".....", "#.#.#", "....."},
+	'▒': {"#.#.#", ".#.#.", "#.#.#", ".#.#.", "#.#.#", ".#.#.", "#.#.#", ".#.#."},
+	'▓': {"#####", "#.#.#", "#####", "#.#.#", "#####", "#.#.#", "#####", "#.#.#"},
+	'▔': {"#####", ".....", ".....", ".....", ".....", ".....", ".....", "....."},
+	'▕': {"....#", "....#", "....#", "....#", "....#", "....#", "....#", "....#"},
+	'▖': {".....", ".....", ".....", ".....", "###..", "###..", "###..", "###.."},
+	'▗': {".....", ".....", ".....", ".....", "...##", "...##", "...##", "...##"},
+	'▘': {"###..", "###..", "###..", "###..", ".....", ".....", ".....", "....."},
+	'▙': {"###..", "###..", "###..", "###..", "#####", "#####", "#####", "#####"},
+	'▚': {"###..", "###..", "###..", "###..", "...##", "...##", "...##", "...##"},
+	'▛': {"#####", "#####", "#####", "#####", "###..", "###..", "###..", "###.."},
+	'▜': {"#####", "#####", "#####", "#####", "...##", "...##", "...##", "...##"},
+	'▝': {"...##", "...##", "...##", "...##", ".....", ".....", ".....", "....."},
+	'▞': {"...##", "...##", "...##", "...##", "###..", "###..", "###..", "###.."},
+	'▟': {"...##", "...##", "...##", "...##", "#####", "#####", "#####", "#####"},
+}
+
+// bitmapGlyph returns glyph of sub-character in built-in bitmap font
+func bitmapGlyph(char rune) [bitmapGlyphHeight]string {
+	if glyph, ok := bitmapGlyphs[char]; ok {
+		return glyph
+	}
+
+	return bitmapUnknown
+}
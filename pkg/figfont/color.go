@@ -0,0 +1,231 @@
+package figfont
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// ColorMode is palette of ANSI escape sequences used to output colors
+type ColorMode int
+
+const (
+	// Color16 uses 16 basic terminal colors
+	Color16 ColorMode = iota
+	// Color256 uses xterm 256 color palette
+	Color256
+	// TrueColor uses 24-bit colors
+	TrueColor
+)
+
+const ansiReset = "\x1b[0m"
+
+// ansiColors are default xterm colors of 16 color palette
+var ansiColors = [16]color.RGBA{
+	{0, 0, 0, 255}, {205, 0, 0, 255}, {0, 205, 0, 255}, {205, 205, 0, 255},
+	{0, 0, 238, 255}, {205, 0, 205, 255}, {0, 205, 205, 255}, {229, 229, 229, 255},
+	{127, 127, 127, 255}, {255, 0, 0, 255}, {0, 255, 0, 255}, {255, 255, 0, 255},
+	{92, 92, 255, 255}, {255, 0, 255, 255}, {0, 255, 255, 255}, {255, 255, 255, 255},
+}
+
+// palettes of TOIlet filters
+var (
+	rainbowColors = []color.RGBA{ansiColors[13], ansiColors[9], ansiColors[11], ansiColors[10], ansiColors[14], ansiColors[12]}
+	metalColors   = []color.RGBA{ansiColors[12], ansiColors[4], ansiColors[7], ansiColors[8]}
+)
+
+// cubeLevels are channel values of 6x6x6 color cube of 256 color palette
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// Rainbow paints sub-characters by diagonal rainbow stripes like TOIlet
+// "gay" filter
+func Rainbow() Filter {
+	return func(rendering Rendering) Rendering {
+		return rendering.paint(func(row, col int) color.RGBA {
+			return rainbowColors[(row+col/2)%len(rainbowColors)]
+		})
+	}
+}
+
+// Metal paints sub-characters by blue and gray stripes like TOIlet
+// "metal" filter
+func Metal() Filter {
+	return func(rendering Rendering) Rendering {
+		return rendering.paint(func(row, col int) color.RGBA {
+			return metalColors[((row+col/8)/2)%len(metalColors)]
+		})
+	}
+}
+
+// Gradient paints rows by colors changing evenly from the top row color
+// to the bottom row one
+func Gradient(from, to color.RGBA) Filter {
+	return func(rendering Rendering) Rendering {
+		steps := len(rendering.Rows) - 1
+		return rendering.paint(func(row, col int) color.RGBA {
+			if steps == 0 {
+				return from
+			}
+
+			return color.RGBA{
+				R: blend(from.R, to.R, row, steps),
+				G: blend(from.G, to.G, row, steps),
+				B: blend(from.B, to.B, row, steps),
+				A: blend(from.A, to.A, row, steps),
+			}
+		})
+	}
+}
+
+func blend(from, to uint8, step, steps int) uint8 {
+	return uint8(int(from) + (int(to)-int(from))*step/steps)
+}
+
+// paint returns rendering with colors of sub-characters set by colorAt.
+// Blanks are kept transparent so background isn't painted
+func (rendering Rendering) paint(colorAt func(row, col int) color.RGBA) Rendering {
+	colors := make([][]color.RGBA, len(rendering.Rows))
+	for row, line := range rendering.Rows {
+		colors[row] = make([]color.RGBA, 0, len(line))
+		for col, char := range []rune(line) {
+			var cellColor color.RGBA
+			if char != ' ' {
+				cellColor = colorAt(row, col)
+			}
+			colors[row] = append(colors[row], cellColor)
+		}
+	}
+	rendering.Colors = colors
+
+	return rendering
+}
+
+// rowColors returns colors of row, nil if rendering isn't painted
+func (rendering Rendering) rowColors(row int) []color.RGBA {
+	if row < len(rendering.Colors) {
+		return rendering.Colors[row]
+	}
+
+	return nil
+}
+
+// cellColor returns color of sub-character, blanks are transparent
+func cellColor(row []rune, colors []color.RGBA, col int) color.RGBA {
+	if row[col] == ' ' || col >= len(colors) || colors[col].A == 0 {
+		return color.RGBA{}
+	}
+
+	return colors[col]
+}
+
+// ANSI returns rows of rendering separated by line breaks with colors set
+// by ANSI escape sequences. Colors are reset before blanks and at the end
+// of every row
+func (rendering Rendering) ANSI(mode ColorMode) string {
+	var text strings.Builder
+	for row, line := range rendering.Rows {
+		if row > 0 {
+			text.WriteByte('\n')
+		}
+
+		var current color.RGBA
+		runes := []rune(line)
+		colors := rendering.rowColors(row)
+		for col, char := range runes {
+			if cellColor := cellColor(runes, colors, col); cellColor != current {
+				if cellColor.A == 0 {
+					text.WriteString(ansiReset)
+				} else {
+					text.WriteString(mode.foreground(cellColor))
+				}
+				current = cellColor
+			}
+			text.WriteRune(char)
+		}
+		if current.A != 0 {
+			text.WriteString(ansiReset)
+		}
+	}
+
+	return text.String()
+}
+
+// foreground returns escape sequence setting foreground color
+func (mode ColorMode) foreground(c color.RGBA) string {
+	switch mode {
+	case TrueColor:
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	case Color256:
+		return fmt.Sprintf("\x1b[38;5;%dm", nearest256(c))
+	default:
+		idx := nearest16(c)
+		if idx < 8 {
+			return fmt.Sprintf("\x1b[%dm", 30+idx)
+		}
+		return fmt.Sprintf("\x1b[%dm", 90+idx-8)
+	}
+}
+
+// nearest16 returns index of the closest color of 16 color palette
+func nearest16(c color.RGBA) int {
+	nearest := 0
+	for idx, paletteColor := range ansiColors {
+		if colorDistance(c, paletteColor) < colorDistance(c, ansiColors[nearest]) {
+			nearest = idx
+		}
+	}
+
+	return nearest
+}
+
+// nearest256 returns index of the closest color of color cube or gray
+// ramp of 256 color palette. Basic colors are skipped as terminals
+// customize them
+func nearest256(c color.RGBA) int {
+	r, g, b := nearestLevel(c.R), nearestLevel(c.G), nearestLevel(c.B)
+	cubeIdx := 16 + 36*r + 6*g + b
+	cubeColor := color.RGBA{cubeLevels[r], cubeLevels[g], cubeLevels[b], 255}
+
+	// gray ramp goes from 8 to 238 by 10
+	average := (int(c.R) + int(c.G) + int(c.B)) / 3
+	gray := (average - 3) / 10
+	if gray < 0 {
+		gray = 0
+	} else if gray > 23 {
+		gray = 23
+	}
+	grayLevel := uint8(8 + 10*gray)
+	grayColor := color.RGBA{grayLevel, grayLevel, grayLevel, 255}
+
+	if colorDistance(c, grayColor) < colorDistance(c, cubeColor) {
+		return 232 + gray
+	}
+
+	return cubeIdx
+}
+
+// nearestLevel returns index of the closest channel value of color cube
+func nearestLevel(value uint8) int {
+	nearest := 0
+	for idx, level := range cubeLevels {
+		if absDiff(value, level) < absDiff(value, cubeLevels[nearest]) {
+			nearest = idx
+		}
+	}
+
+	return nearest
+}
+
+func colorDistance(a, b color.RGBA) int {
+	dr, dg, db := absDiff(a.R, b.R), absDiff(a.G, b.G), absDiff(a.B, b.B)
+
+	return dr*dr + dg*dg + db*db
+}
+
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+
+	return int(b - a)
+}
@@ -0,0 +1,117 @@
+package figfont
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestRenderingANSI(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	blue := color.RGBA{0, 0, 255, 255}
+	rendering := Rendering{Rows: []string{"ab c", "d"}}
+	rendering.Colors = [][]color.RGBA{{red, red, {}, blue}, {blue}}
+
+	testCases := []struct {
+		name     string
+		mode     ColorMode
+		expected string
+	}{
+		{"16 colors", Color16, "\x1b[91mab\x1b[0m \x1b[34mc\x1b[0m\n\x1b[34md\x1b[0m"},
+		{"256 colors", Color256, "\x1b[38;5;196mab\x1b[0m \x1b[38;5;21mc\x1b[0m\n\x1b[38;5;21md\x1b[0m"},
+		{
+			"true color",
+			TrueColor,
+			"\x1b[38;2;255;0;0mab\x1b[0m \x1b[38;2;0;0;255mc\x1b[0m\n\x1b[38;2;0;0;255md\x1b[0m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assertStringEqual(t, testCase.expected, rendering.ANSI(testCase.mode))
+		})
+	}
+}
+
+func TestRenderingANSIWithoutColors(t *testing.T) {
+	rendering := Rendering{Rows: []string{"ab", "c"}}
+	assertStringEqual(t, "ab\nc", rendering.ANSI(TrueColor))
+}
+
+func TestNearest256(t *testing.T) {
+	testCases := []struct {
+		color    color.RGBA
+		expected int
+	}{
+		{color.RGBA{0, 0, 0, 255}, 16},
+		{color.RGBA{255, 255, 255, 255}, 231},
+		{color.RGBA{95, 135, 175, 255}, 67},
+		{color.RGBA{128, 128, 128, 255}, 244},
+	}
+
+	for _, testCase := range testCases {
+		assertIntEqual(t, testCase.expected, nearest256(testCase.color))
+	}
+}
+
+func TestColorFilters(t *testing.T) {
+	rendering := Rendering{Rows: []string{"a b", "cd", "e"}}
+	from := color.RGBA{0, 0, 0, 255}
+	to := color.RGBA{200, 100, 0, 255}
+	none := color.RGBA{}
+
+	testCases := []struct {
+		name     string
+		filter   Filter
+		expected [][]color.RGBA
+	}{
+		{
+			"rainbow",
+			Rainbow(),
+			[][]color.RGBA{
+				{rainbowColors[0], none, rainbowColors[1]},
+				{rainbowColors[1], rainbowColors[1]},
+				{rainbowColors[2]},
+			},
+		},
+		{
+			"metal",
+			Metal(),
+			[][]color.RGBA{
+				{metalColors[0], none, metalColors[0]},
+				{metalColors[0], metalColors[0]},
+				{metalColors[1]},
+			},
+		},
+		{
+			"gradient",
+			Gradient(from, to),
+			[][]color.RGBA{
+				{from, none, from},
+				{{100, 50, 0, 255}, {100, 50, 0, 255}},
+				{to},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			painted := rendering.Apply(testCase.filter)
+			if !reflect.DeepEqual(testCase.expected, painted.Colors) {
+				t.Errorf("want %v but got %v", testCase.expected, painted.Colors)
+			}
+			if rendering.Colors != nil {
+				t.Errorf("filter modified source rendering")
+			}
+		})
+	}
+}
+
+func TestGradientSingleRow(t *testing.T) {
+	from := color.RGBA{10, 20, 30, 255}
+	rendering := Rendering{Rows: []string{"ab"}}.Apply(Gradient(from, color.RGBA{}))
+	expected := [][]color.RGBA{{from, from}}
+	if !reflect.DeepEqual(expected, rendering.Colors) {
+		t.Errorf("want %v but got %v", expected, rendering.Colors)
+	}
+}
@@ -0,0 +1,581 @@
+package figfont
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const controlFileSignature = "flc2"
+
+// InputEncoding defines how bytes of phrase are converted to character codes
+type InputEncoding int
+
+const (
+	// EncodingAuto means encoding is not set by control file, phrase is read as UTF-8
+	EncodingAuto InputEncoding = iota
+	// EncodingUTF8 reads phrase as UTF-8, command "u"
+	EncodingUTF8
+	// Encoding8Bit reads every byte as separate character, command "b"
+	Encoding8Bit
+	// EncodingISO2022 reads phrase as ISO 2022 stream, command "g"
+	EncodingISO2022
+	// EncodingHZ reads phrase as HZ encoded DBCS, command "h"
+	EncodingHZ
+	// EncodingShiftJIS reads phrase as Shift-JIS encoded DBCS, command "j"
+	EncodingShiftJIS
+)
+
+// ControlFile contains character mapping and input encoding commands of
+// FIGlet control file
+type ControlFile struct {
+	Encoding InputEncoding
+	commands []controlCommand
+	iso2022  iso2022State
+}
+
+// controlCommand translates range of characters by offset or
+// freezes translations made by previous commands
+type controlCommand struct {
+	freeze bool
+	low    int
+	high   int
+	offset int
+}
+
+// iso2022State contains character sets designated to G0-G3 and
+// sets invoked into GL and GR
+type iso2022State struct {
+	sets       [4]int
+	doubleByte [4]bool
+	gl         int
+	gr         int
+}
+
+func newISO2022State() iso2022State {
+	return iso2022State{sets: [4]int{0, 0x80, 0, 0}, gl: 0, gr: 1}
+}
+
+// ParseControlFile reads FIGlet control file (.flc)
+func ParseControlFile(r io.Reader) (ControlFile, error) {
+	control := ControlFile{iso2022: newISO2022State()}
+
+	buf := bufio.NewScanner(r)
+	if !buf.Scan() || !strings.HasPrefix(buf.Text(), controlFileSignature) {
+		return control, errors.New("bad control file signature")
+	}
+
+	for lineNum := 2; buf.Scan(); lineNum++ {
+		line := strings.TrimLeft(buf.Text(), " \t")
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		if err := control.parseCommand(line); err != nil {
+			return control, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+	}
+
+	return control, buf.Err()
+}
+
+func (control *ControlFile) parseCommand(line string) error {
+	reader := &controlReader{chars: []rune(line)}
+	command := reader.next()
+
+	switch {
+	case command == 't':
+		return control.parseTranslation(reader)
+	case command == '-' || (command >= '0' && command <= '9'):
+		reader.pos--
+		return control.parseNumericTranslation(reader)
+	case command == 'f':
+		control.commands = append(control.commands, controlCommand{freeze: true})
+	case command == 'b':
+		control.Encoding = Encoding8Bit
+	case command == 'u':
+		control.Encoding = EncodingUTF8
+	case command == 'h':
+		control.Encoding = EncodingHZ
+	case command == 'j':
+		control.Encoding = EncodingShiftJIS
+	case command == 'g':
+		control.Encoding = EncodingISO2022
+		return control.parseISO2022(reader)
+	default:
+		return fmt.Errorf("unknown command '%c'", command)
+	}
+
+	return nil
+}
+
+// parseTranslation parses "t" command: t from[-to] target[-target_end]
+func (control *ControlFile) parseTranslation(reader *controlReader) error {
+	reader.skipSpaces()
+	low, high, err := reader.charRange()
+	if err != nil {
+		return err
+	}
+	reader.skipSpaces()
+	target, targetEnd, err := reader.charRange()
+	if err != nil {
+		return err
+	}
+	if targetEnd-target != high-low {
+		return errors.New("ranges have different length")
+	}
+
+	control.commands = append(control.commands, controlCommand{low: low, high: high, offset: target - low})
+
+	return nil
+}
+
+// parseNumericTranslation parses translation given by two numbers: from target
+func (control *ControlFile) parseNumericTranslation(reader *controlReader) error {
+	code, err := reader.number()
+	if err != nil {
+		return err
+	}
+	reader.skipSpaces()
+	target, err := reader.number()
+	if err != nil {
+		return err
+	}
+
+	control.commands = append(control.commands, controlCommand{low: code, high: code, offset: target - code})
+
+	return nil
+}
+
+// parseISO2022 parses "g" command which designates character set to G0-G3
+// or invokes G0-G3 into GL or GR
+func (control *ControlFile) parseISO2022(reader *controlReader) error {
+	reader.skipSpaces()
+	sub := reader.next()
+	switch sub {
+	case 0:
+		// bare "g" only switches input to ISO 2022
+	case '0', '1', '2', '3':
+		set := int(sub - '0')
+		reader.skipSpaces()
+		size := reader.word()
+		reader.skipSpaces()
+		designator := reader.next()
+		if designator == 0 {
+			return errors.New("missing character set designator")
+		}
+
+		switch size {
+		case "94":
+			control.iso2022.designate(set, byte(designator), false, false)
+		case "96":
+			control.iso2022.designate(set, byte(designator), true, false)
+		case "94x94":
+			control.iso2022.designate(set, byte(designator), false, true)
+		default:
+			return fmt.Errorf("unknown character set size '%s'", size)
+		}
+	case 'L', 'l', 'R', 'r':
+		reader.skipSpaces()
+		set := reader.next()
+		if set < '0' || set > '3' {
+			return fmt.Errorf("bad character set number '%c'", set)
+		}
+		if sub == 'L' || sub == 'l' {
+			control.iso2022.gl = int(set - '0')
+		} else {
+			control.iso2022.gr = int(set - '0')
+		}
+	default:
+		return fmt.Errorf("unknown ISO 2022 command '%c'", sub)
+	}
+
+	return nil
+}
+
+// mapChar translates character code by commands. The first matched
+// translation is applied and the rest of commands up to freeze are skipped
+func (control ControlFile) mapChar(code int) int {
+	for idx := 0; idx < len(control.commands); idx++ {
+		command := control.commands[idx]
+		if command.freeze || code < command.low || code > command.high {
+			continue
+		}
+
+		code += command.offset
+		for idx+1 < len(control.commands) && !control.commands[idx+1].freeze {
+			idx++
+		}
+	}
+
+	return code
+}
+
+// applyControlFiles decodes phrase according to input encoding of
+// control files and translates characters by all control files in order
+func applyControlFiles(phrase string, controls []ControlFile) []rune {
+	encoding, iso2022 := EncodingAuto, newISO2022State()
+	for _, control := range controls {
+		if control.Encoding != EncodingAuto {
+			encoding, iso2022 = control.Encoding, control.iso2022
+		}
+	}
+
+	codes := decodePhrase(phrase, encoding, iso2022)
+	for _, control := range controls {
+		for idx, code := range codes {
+			codes[idx] = control.mapChar(code)
+		}
+	}
+
+	chars := make([]rune, len(codes))
+	for idx, code := range codes {
+		chars[idx] = rune(code)
+	}
+
+	return chars
+}
+
+// decodePhrase converts phrase to character codes according to input encoding
+func decodePhrase(phrase string, encoding InputEncoding, iso2022 iso2022State) []int {
+	var codes []int
+	data := []byte(phrase)
+
+	switch encoding {
+	case Encoding8Bit:
+		for _, char := range data {
+			codes = append(codes, int(char))
+		}
+	case EncodingShiftJIS:
+		for idx := 0; idx < len(data); idx++ {
+			code := int(data[idx])
+			if ((code >= 0x80 && code <= 0x9F) || (code >= 0xE0 && code <= 0xEF)) && idx+1 < len(data) {
+				idx++
+				code = code<<8 | int(data[idx])
+			}
+			codes = append(codes, code)
+		}
+	case EncodingHZ:
+		codes = decodeHZ(data)
+	case EncodingISO2022:
+		codes = iso2022.decode(data)
+	default:
+		for _, char := range phrase {
+			codes = append(codes, int(char))
+		}
+	}
+
+	return codes
+}
+
+// decodeHZ converts HZ encoded data, GB2312 characters are returned
+// as EUC-CN codes
+func decodeHZ(data []byte) []int {
+	var codes []int
+	doubleByte := false
+
+	for idx := 0; idx < len(data); idx++ {
+		code := int(data[idx])
+		if code == '~' && idx+1 < len(data) {
+			switch data[idx+1] {
+			case '{':
+				doubleByte = true
+				idx++
+				continue
+			case '}':
+				doubleByte = false
+				idx++
+				continue
+			case '\n':
+				// line continuation
+				idx++
+				continue
+			case '~':
+				idx++
+			}
+		} else if doubleByte && code > ' ' && idx+1 < len(data) {
+			idx++
+			code = (code<<8 | int(data[idx])) | 0x8080
+		}
+		codes = append(codes, code)
+	}
+
+	return codes
+}
+
+// decode converts ISO 2022 data. Characters of single byte sets are returned
+// as set<<16 | char, characters of double byte sets as set<<16 | char<<8 | char2
+func (state iso2022State) decode(data []byte) []int {
+	var codes []int
+	singleShift := -1
+
+	for idx := 0; idx < len(data); idx++ {
+		code := int(data[idx])
+		switch code {
+		case 14: // SO: invoke G1 into GL
+			state.gl = 1
+			continue
+		case 15: // SI: invoke G0 into GL
+			state.gl = 0
+			continue
+		case 27: // ESC
+			idx = state.escape(data, idx+1, &singleShift)
+			continue
+		}
+
+		gl, gr := state.gl, state.gr
+		if singleShift >= 0 {
+			gl, gr = singleShift, singleShift
+			singleShift = -1
+		}
+
+		switch {
+		case code >= 0x21 && code <= 0x7E:
+			code = state.char(gl, code, data, &idx)
+		case code >= 0xA0 && code <= 0xFF:
+			code = state.char(gr, code&^0x80, data, &idx)
+		}
+		codes = append(codes, code)
+	}
+
+	return codes
+}
+
+// char converts character of set, second byte is read for double byte sets
+func (state iso2022State) char(set int, code int, data []byte, idx *int) int {
+	if state.doubleByte[set] {
+		if *idx+1 >= len(data) {
+			return state.sets[set] | code
+		}
+		*idx++
+		return state.sets[set] | code<<8 | int(data[*idx]&^0x80)
+	}
+
+	return state.sets[set] | code
+}
+
+// escape processes escape sequence starting at idx and returns index of
+// its last byte
+func (state *iso2022State) escape(data []byte, idx int, singleShift *int) int {
+	if idx >= len(data) {
+		return idx
+	}
+
+	switch data[idx] {
+	case 'N': // SS2
+		*singleShift = 2
+	case 'O': // SS3
+		*singleShift = 3
+	case 'n':
+		state.gl = 2
+	case 'o':
+		state.gl = 3
+	case '~':
+		state.gr = 1
+	case '}':
+		state.gr = 2
+	case '|':
+		state.gr = 3
+	case '(', ')', '*', '+':
+		if idx+1 < len(data) {
+			set := int(data[idx] - '(')
+			state.designate(set, data[idx+1], false, false)
+			idx++
+		}
+	case '-', '.', '/':
+		if idx+1 < len(data) {
+			set := int(data[idx]-'-') + 1
+			state.designate(set, data[idx+1], true, false)
+			idx++
+		}
+	case '$':
+		if idx+1 >= len(data) {
+			return idx
+		}
+		idx++
+		switch data[idx] {
+		case '@', 'A', 'B':
+			state.designate(0, data[idx], false, true)
+		case '(', ')', '*', '+':
+			if idx+1 < len(data) {
+				set := int(data[idx] - '(')
+				state.designate(set, data[idx+1], false, true)
+				idx++
+			}
+		}
+	}
+
+	return idx
+}
+
+// designate assigns character set to G0-G3. ASCII and the top half of
+// Latin-1 are kept as plain character codes
+func (state *iso2022State) designate(set int, designator byte, is96 bool, doubleByte bool) {
+	code := int(designator) << 16
+	switch {
+	case doubleByte:
+	case is96 && designator == 'A':
+		code = 0x80
+	case is96:
+		code |= 0x80
+	case designator == 'B':
+		code = 0
+	}
+
+	state.sets[set] = code
+	state.doubleByte[set] = doubleByte
+}
+
+// controlReader reads arguments of control file commands
+type controlReader struct {
+	chars []rune
+	pos   int
+}
+
+// next returns next character or zero at the end of line
+func (reader *controlReader) next() rune {
+	if reader.pos >= len(reader.chars) {
+		return 0
+	}
+	reader.pos++
+
+	return reader.chars[reader.pos-1]
+}
+
+func (reader *controlReader) peek() rune {
+	if reader.pos >= len(reader.chars) {
+		return 0
+	}
+
+	return reader.chars[reader.pos]
+}
+
+func (reader *controlReader) skipSpaces() {
+	for reader.peek() == ' ' || reader.peek() == '\t' {
+		reader.pos++
+	}
+}
+
+// word reads characters up to space
+func (reader *controlReader) word() string {
+	start := reader.pos
+	for reader.peek() != 0 && reader.peek() != ' ' && reader.peek() != '\t' {
+		reader.pos++
+	}
+
+	return string(reader.chars[start:reader.pos])
+}
+
+// charRange reads character or range of characters: a-z
+func (reader *controlReader) charRange() (low, high int, err error) {
+	low, err = reader.char()
+	if err != nil {
+		return 0, 0, err
+	}
+	if reader.peek() != '-' {
+		return low, low, nil
+	}
+
+	reader.pos++
+	high, err = reader.char()
+	if err != nil {
+		return 0, 0, err
+	}
+	if high < low {
+		return 0, 0, errors.New("bad character range")
+	}
+
+	return low, high, nil
+}
+
+// char reads literal character or escape sequence
+func (reader *controlReader) char() (int, error) {
+	char := reader.next()
+	if char == 0 {
+		return 0, errors.New("missing character")
+	}
+	if char != '\\' {
+		return int(char), nil
+	}
+
+	escaped := reader.next()
+	switch escaped {
+	case 'a':
+		return 7, nil
+	case 'b':
+		return 8, nil
+	case 'e':
+		return 27, nil
+	case 'f':
+		return 12, nil
+	case 'n':
+		return 10, nil
+	case 'r':
+		return 13, nil
+	case 't':
+		return 9, nil
+	case 'v':
+		return 11, nil
+	case 0:
+		return '\\', nil
+	}
+	if escaped == '-' || escaped == 'x' || escaped == 'X' || (escaped >= '0' && escaped <= '9') {
+		reader.pos--
+		return reader.number()
+	}
+
+	return int(escaped), nil
+}
+
+// number reads decimal, octal (0 prefix) or hexadecimal (0x prefix) number
+func (reader *controlReader) number() (int, error) {
+	sign := 1
+	if reader.peek() == '-' {
+		sign = -1
+		reader.pos++
+	}
+
+	base := 10
+	if reader.peek() == '0' {
+		reader.pos++
+		base = 8
+		if reader.peek() == 'x' || reader.peek() == 'X' {
+			reader.pos++
+			base = 16
+		}
+	} else if reader.peek() == 'x' || reader.peek() == 'X' {
+		reader.pos++
+		base = 16
+	}
+
+	value, digits := 0, 0
+	for {
+		digit := digitValue(reader.peek())
+		if digit < 0 || digit >= base {
+			break
+		}
+		value = value*base + digit
+		digits++
+		reader.pos++
+	}
+	if digits == 0 && base != 8 {
+		return 0, errors.New("bad number")
+	}
+
+	return sign * value, nil
+}
+
+func digitValue(char rune) int {
+	switch {
+	case char >= '0' && char <= '9':
+		return int(char - '0')
+	case char >= 'a' && char <= 'f':
+		return int(char-'a') + 10
+	case char >= 'A' && char <= 'F':
+		return int(char-'A') + 10
+	}
+
+	return -1
+}
@@ -0,0 +1,105 @@
+package figfont
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseControlFileTranslations(t *testing.T) {
+	testCases := []struct {
+		name    string
+		control string
+		chars   map[int]int
+	}{
+		{"character", "t a b", map[int]int{'a': 'b', 'b': 'b', 'c': 'c'}},
+		{"range", "t a-z A-Z", map[int]int{'a': 'A', 'q': 'Q', 'z': 'Z', 'A': 'A', '1': '1'}},
+		{"escapes", "t \\65 \\0x62\nt \\  _\nt \\t \\-1", map[int]int{'A': 'b', ' ': '_', '\t': -1}},
+		{"numbers", "0x41 0141\n-2 066", map[int]int{'A': 'a', -2: '6'}},
+		{"utf-8 characters", "t Ж X", map[int]int{'Ж': 'X'}},
+		{"first match wins", "t a b\nt b c", map[int]int{'a': 'b', 'b': 'c'}},
+		{"freeze", "t a b\nf\nt b c", map[int]int{'a': 'c', 'b': 'c'}},
+		{"comments", "# t a b\n\n  t a c", map[int]int{'a': 'c'}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			control, err := ParseControlFile(strings.NewReader("flc2a\n" + testCase.control))
+			assertNoError(t, err)
+
+			for char, mapped := range testCase.chars {
+				assertIntEqual(t, mapped, control.mapChar(char))
+			}
+		})
+	}
+}
+
+func TestParseControlFileErrors(t *testing.T) {
+	testCases := []struct {
+		control string
+		err     string
+	}{
+		{"flf2a\nt a b", "bad control file signature"},
+		{"", "bad control file signature"},
+		{"flc2a\nz", "line 2: unknown command 'z'"},
+		{"flc2a\n# comment\nt a", "line 3: missing character"},
+		{"flc2a\nt a-c x-y", "line 2: ranges have different length"},
+		{"flc2a\nt c-a x-z", "line 2: bad character range"},
+		{"flc2a\n0x4G 1", "line 2: bad number"},
+		{"flc2a\ng 1 95 B", "line 2: unknown character set size '95'"},
+		{"flc2a\ng L 4", "line 2: bad character set number '4'"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.err, func(t *testing.T) {
+			_, err := ParseControlFile(strings.NewReader(testCase.control))
+			assertError(t, err, testCase.err)
+		})
+	}
+}
+
+func TestApplyControlFiles(t *testing.T) {
+	testCases := []struct {
+		name     string
+		controls []string
+		phrase   string
+		chars    []rune
+	}{
+		{"without encoding", []string{"t a-z A-Z"}, "abé", []rune{'A', 'B', 'é'}},
+		{"utf-8", []string{"u"}, "é", []rune{'é'}},
+		{"8-bit", []string{"b"}, "é", []rune{0xC3, 0xA9}},
+		{"shift-jis", []string{"j"}, "a\x82\xa0b", []rune{'a', 0x82A0, 'b'}},
+		{"hz", []string{"h"}, "a~{<:~}b~~", []rune{'a', 0xBCBA, 'b', '~'}},
+		{"iso 2022", []string{"g"}, "a\x1b$B$\"\x1b(Bb\xe9", []rune{'a', 'B'<<16 | 0x2422, 'b', 0xE9}},
+		{"iso 2022 shift out", []string{"g 1 94 I\ng R 2"}, "\x0e1\x0f1", []rune{'I'<<16 | '1', '1'}},
+		{"iso 2022 double byte set", []string{"g 0 94x94 B"}, "$\"", []rune{'B'<<16 | 0x2422}},
+		{"chain", []string{"t a b", "t b c"}, "ab", []rune{'c', 'c'}},
+		{"the last encoding wins", []string{"b", "u"}, "é", []rune{'é'}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var controls []ControlFile
+			for _, data := range testCase.controls {
+				control, err := ParseControlFile(strings.NewReader("flc2a\n" + data))
+				assertNoError(t, err)
+				controls = append(controls, control)
+			}
+
+			chars := applyControlFiles(testCase.phrase, controls)
+			if !reflect.DeepEqual(testCase.chars, chars) {
+				t.Errorf("want %x but got %x", testCase.chars, chars)
+			}
+		})
+	}
+}
+
+func TestFIGFontPrintWithControlFiles(t *testing.T) {
+	control, err := ParseControlFile(strings.NewReader("flc2a\nt a-z A-Z\n"))
+	assertNoError(t, err)
+
+	font := testFont(Layout{})
+	printed, err := font.PrintWithOptions("li", PrintOptions{ControlFiles: []ControlFile{control}})
+	assertNoError(t, err)
+	assertStringEqual(t, "|   |\n|   |\n|__ |", printed)
+}
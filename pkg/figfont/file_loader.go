@@ -1,16 +1,21 @@
 package figfont
 
 import (
+	"archive/zip"
 	"bufio"
+	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 //           flf2a$ 6 5 20 15 3 0 143 229    NOTE: The first five characters in
-//             |  | | | |  |  | |  |   |     the entire file must be "flf2a".
-//            /  /  | | |  |  | |  |   \
+//             |  | | | |  |  | |  |   |     the entire file must be "flf2a"
+//            /  /  | | |  |  | |  |   \     or "tlf2a" for TOIlet fonts.
 //   Signature  /  /  | |  |  | |   \   Codetag_Count
 //     Hardblank  /  /  |  |  |  \   Full_Layout*
 //          Height  /   |  |   \  Print_Direction
@@ -18,23 +23,93 @@ import (
 //           Max_Length      Old_Layout*
 
 const fontSignature = "flf2"
+const tlfFontSignature = "tlf2"
 const endmarkChars = "@#%$"
+const zipSignature = "PK\x03\x04"
+
+// maxZipSize and maxFontSize limit sizes of compressed font archive and
+// unpacked font file
+const (
+	maxZipSize  = 16 << 20
+	maxFontSize = 64 << 20
+)
 
 // FileLoader is set of data to load font from file
 type FileLoader struct {
 	font         FIGFont
 	commentLines int
 	buf          *bufio.Scanner
+	line         int
 }
 
-// NewFileLoader creates FIG font loader from file
+// NewFileLoader creates FIG font loader from file.
+// Font file compressed as ZIP archive is unpacked transparently
 func NewFileLoader(r io.Reader) (FileLoader, error) {
+	r, err := uncompress(r)
+	if err != nil {
+		return FileLoader{}, err
+	}
+
 	loader := FileLoader{font: FIGFont{}, buf: bufio.NewScanner(r)}
 	loader.font.Letters = make(map[int][]string)
 
 	return loader, nil
 }
 
+// uncompress detects ZIP archive and returns reader of its first file,
+// other data is returned as is
+func uncompress(r io.Reader) (io.Reader, error) {
+	buf := bufio.NewReader(r)
+	signature, _ := buf.Peek(len(zipSignature))
+	if string(signature) != zipSignature {
+		return buf, nil
+	}
+
+	data, err := readLimited(buf, maxZipSize, "zip archive")
+	if err != nil {
+		return nil, err
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range archive.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		entry, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer entry.Close()
+
+		data, err := readLimited(entry, maxFontSize, "font in zip archive")
+		if err != nil {
+			return nil, err
+		}
+
+		return bytes.NewReader(data), nil
+	}
+
+	return nil, errors.New("zip archive has no files")
+}
+
+// readLimited reads data up to limit, larger data is rejected to protect
+// from zip bombs
+func readLimited(r io.Reader, limit int64, name string) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s is larger than %d bytes", name, limit)
+	}
+
+	return data, nil
+}
+
 // Parse read font data and fill all necessary attributes to use font in future
 func (loader *FileLoader) Parse() (FIGFont, error) {
 	err := loader.parseHeaders()
@@ -50,105 +125,211 @@ func (loader *FileLoader) Parse() (FIGFont, error) {
 	return loader.font, nil
 }
 
+// headerFields are names of header fields after signature and hardblank
+var headerFields = []string{
+	"Height", "Baseline", "Max_Length", "Old_Layout", "Comment_Lines",
+	"Print_Direction", "Full_Layout", "Codetag_Count",
+}
+
 func (loader *FileLoader) parseHeaders() error {
-	loader.buf.Scan()
-	header := loader.buf.Text()
-	if header[:len(fontSignature)] != fontSignature {
-		return errors.New("bad font signature")
+	header, ok := loader.scan()
+	if !ok {
+		return loader.scanError("missing header")
+	}
+	if !strings.HasPrefix(header, fontSignature) && !strings.HasPrefix(header, tlfFontSignature) {
+		return loader.parseError("bad font signature")
 	}
 
 	fields := strings.Fields(header)
-	loader.font.Hardblank = fields[0][len(fields[0])-1:]
-
-	var err error
-	loader.font.Height, err = strconv.Atoi(fields[1])
-	if err != nil {
-		return err
-	}
-	loader.font.Baseline, err = strconv.Atoi(fields[2])
-	if err != nil {
-		return err
+	if len(fields) < 6 {
+		return loader.errorf("header has %d fields but at least 6 are required", len(fields))
 	}
-	loader.commentLines, err = strconv.Atoi(fields[5])
-	if err != nil {
-		return err
+	if len(fields[0]) <= len(fontSignature)+1 {
+		return loader.parseError("missing hardblank")
 	}
-	if len(fields) > 6 {
-		loader.font.PrintDirection, err = strconv.Atoi(fields[6])
+	hardblank, _ := utf8.DecodeLastRuneInString(fields[0])
+	loader.font.Hardblank = string(hardblank)
+
+	var values [8]int
+	for idx, field := range fields[1:] {
+		if idx >= len(values) {
+			break
+		}
+		value, err := strconv.Atoi(field)
 		if err != nil {
-			return err
+			return loader.errorf("bad %s '%s'", headerFields[idx], field)
 		}
+		values[idx] = value
+	}
+
+	loader.font.Height = values[0]
+	loader.font.Baseline = values[1]
+	loader.font.MaxLength = values[2]
+	loader.font.Layout = newOldLayout(values[3])
+	loader.commentLines = values[4]
+	loader.font.PrintDirection = values[5]
+	if len(fields) > 7 {
+		// Full_Layout takes priority over Old_Layout
+		loader.font.Layout = newFullLayout(values[6])
+	}
+	loader.font.CodetagCount = values[7]
+
+	if loader.font.Height < 1 {
+		return loader.errorf("bad Height '%d'", loader.font.Height)
+	}
+	if loader.commentLines < 0 {
+		return loader.errorf("bad Comment_Lines '%d'", loader.commentLines)
 	}
 
 	return nil
 }
 
 func (loader *FileLoader) parseLetters() error {
-	// skip comment lines
+	var comment []string
 	for i := 0; i < loader.commentLines; i++ {
-		loader.buf.Scan()
+		line, ok := loader.scan()
+		if !ok {
+			return loader.scanError("unexpected end of file in comments")
+		}
+		comment = append(comment, line)
 	}
+	loader.font.Comment = strings.Join(comment, "\n")
 
 	var lineEndChar string
 	var letter []string
-	charCode := 32
+	requiredCodes := requiredCharCodes()
+	letterIdx := 0
+	charCode := requiredCodes[0]
+	codeTagged := false
+	// every letter after required ones is preceded by its code tag
+	expectCodeTag := false
 
-	for loader.buf.Scan() {
-		line := loader.buf.Text()
+	for {
+		line, ok := loader.scan()
+		if !ok {
+			break
+		}
 
 		if len(line) == 0 {
+			if len(letter) > 0 {
+				return loader.glyphError(charCode, "row without endmark")
+			}
 			continue
 		}
 
-		if strings.ContainsAny(line[len(line)-1:], endmarkChars) {
+		endmark, endmarkSize := utf8.DecodeLastRuneInString(line)
+		isCodeTag := len(letter) == 0 && (expectCodeTag || !strings.ContainsRune(endmarkChars, endmark))
+		if !isCodeTag {
 			// Letter
 			if len(lineEndChar) == 0 {
 				// start of letter
-				lineEndChar = line[len(line)-1:]
+				lineEndChar = string(endmark)
 			}
-			if (len(line) > 1 && line[len(line)-2:] == strings.Repeat(lineEndChar, 2)) || len(letter) >= loader.font.Height-1 {
-				endLength := 2
-				if loader.font.Height == 1 {
-					endLength = 1
-				}
-				letter = append(letter, line[:len(line)-endLength])
+			row := line[:len(line)-endmarkSize]
+			if strings.HasSuffix(row, lineEndChar) || len(letter) >= loader.font.Height-1 {
+				letter = append(letter, strings.TrimSuffix(row, lineEndChar))
 				loader.font.Letters[charCode] = letter
 				letter = []string{}
 				lineEndChar = ""
-				charCode++
+
+				letterIdx++
+				if !codeTagged && letterIdx < len(requiredCodes) {
+					charCode = requiredCodes[letterIdx]
+				} else {
+					codeTagged, expectCodeTag = true, true
+				}
 			} else {
-				letter = append(letter, line[:len(line)-1])
+				letter = append(letter, row)
 			}
 		} else {
 			// extended char code
 			var err error
-			charCode, err = parseCharCode(line)
+			var comment string
+			charCode, comment, err = parseCharCode(line)
 			if err != nil {
-				return err
+				return loader.errorf("bad code tag '%s'", line)
 			}
+			if comment != "" {
+				if loader.font.CodetagComments == nil {
+					loader.font.CodetagComments = make(map[int]string)
+				}
+				loader.font.CodetagComments[charCode] = comment
+			}
+			codeTagged, expectCodeTag = true, false
 		}
 	}
 
+	if err := loader.buf.Err(); err != nil {
+		return loader.parseError(err.Error())
+	}
+	if len(letter) > 0 {
+		return loader.glyphError(charCode, fmt.Sprintf("unexpected end of file after %d of %d rows", len(letter), loader.font.Height))
+	}
+	if codeTagged && !expectCodeTag {
+		return loader.glyphError(charCode, "missing FIGcharacter after code tag")
+	}
+
 	return nil
 }
 
-func parseCharCode(line string) (int, error) {
-	substrings := strings.SplitN(line, " ", 2)
+// scan reads the next line of font file and counts lines
+func (loader *FileLoader) scan() (string, bool) {
+	if !loader.buf.Scan() {
+		return "", false
+	}
+	loader.line++
+
+	return loader.buf.Text(), true
+}
+
+// scanError returns read error if any, otherwise parse error with reason
+func (loader *FileLoader) scanError(reason string) error {
+	if err := loader.buf.Err(); err != nil {
+		reason = err.Error()
+	}
+
+	return &ParseError{Line: loader.line + 1, Reason: reason}
+}
+
+func (loader *FileLoader) parseError(reason string) error {
+	return &ParseError{Line: loader.line, Reason: reason}
+}
+
+func (loader *FileLoader) errorf(format string, args ...interface{}) error {
+	return loader.parseError(fmt.Sprintf(format, args...))
+}
+
+func (loader *FileLoader) glyphError(code int, reason string) error {
+	return &ParseError{Line: loader.line, Glyph: true, Code: code, Reason: reason}
+}
+
+// parseCharCode parses code tag line: decimal, octal (0 prefix) or
+// hexadecimal (0x prefix) code optionally followed by comment
+func parseCharCode(line string) (int, string, error) {
+	charCodeStr, comment := line, ""
+	if idx := strings.IndexAny(line, " \t"); idx >= 0 {
+		charCodeStr, comment = line[:idx], strings.TrimSpace(line[idx:])
+	}
+
+	sign := int64(1)
+	if strings.HasPrefix(charCodeStr, "-") {
+		sign = -1
+		charCodeStr = charCodeStr[1:]
+	}
 
 	base := 10
-	charCodeStr := substrings[0]
-	if charCodeStr[:2] == "0x" {
+	if strings.HasPrefix(charCodeStr, "0x") || strings.HasPrefix(charCodeStr, "0X") {
 		base = 16
 		charCodeStr = charCodeStr[2:]
-	} else if charCodeStr[:1] == "0" {
+	} else if len(charCodeStr) > 1 && charCodeStr[0] == '0' {
 		base = 8
 		charCodeStr = charCodeStr[1:]
 	}
 
-	charCode, err := strconv.ParseInt(charCodeStr, base, 16)
+	charCode, err := strconv.ParseInt(charCodeStr, base, 32)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
-	return int(charCode), nil
+	return int(sign * charCode), comment, nil
 }
@@ -1,7 +1,9 @@
 package figfont
 
 import (
+	"archive/zip"
 	"bufio"
+	"bytes"
 	"fmt"
 	"reflect"
 	"strings"
@@ -20,6 +22,15 @@ func TestFileLoaderCheckSignature(t *testing.T) {
 		assertNoError(t, err)
 	})
 
+	t.Run("TOIlet signature", func(t *testing.T) {
+		header := strings.NewReader("tlf2a$ 6 5 20 15 3 0 143 229")
+		loader, err := NewFileLoader(header)
+		assertNoError(t, err)
+
+		err = loader.parseHeaders()
+		assertNoError(t, err)
+	})
+
 	badSignatures := []string{
 		"fIf2a$ 6 5 20 15 3 0 143 229",
 		"flf3a$ 6 5 20 15 3 0 143 229",
@@ -33,7 +44,7 @@ func TestFileLoaderCheckSignature(t *testing.T) {
 			assertNoError(t, err)
 
 			err = loader.parseHeaders()
-			assertError(t, err, "bad font signature")
+			assertError(t, err, "line 1: bad font signature")
 		})
 	}
 }
@@ -46,6 +57,9 @@ func TestFileLoaderHeaderParse(t *testing.T) {
 		baseline       int
 		commentLines   int
 		printDirection int
+		maxLength      int
+		layout         Layout
+		codetagCount   int
 	}{
 		{
 			correctFontSignature,
@@ -54,6 +68,9 @@ func TestFileLoaderHeaderParse(t *testing.T) {
 			5,
 			3,
 			0,
+			20,
+			Layout{Horizontal: Smushing, HorizontalRules: 15},
+			229,
 		},
 		{
 			"flf2a% 18 128 0 0 512 -1",
@@ -62,6 +79,9 @@ func TestFileLoaderHeaderParse(t *testing.T) {
 			128,
 			512,
 			-1,
+			0,
+			Layout{Horizontal: Fitting},
+			0,
 		},
 		{
 			"flf2a~ 312 5 54 32 5 1",
@@ -70,6 +90,9 @@ func TestFileLoaderHeaderParse(t *testing.T) {
 			5,
 			5,
 			1,
+			54,
+			Layout{Horizontal: Smushing, HorizontalRules: SmushHardblank},
+			0,
 		},
 		{
 			"flf2a1 8 6 7 3 3", // default print direction
@@ -78,6 +101,47 @@ func TestFileLoaderHeaderParse(t *testing.T) {
 			6,
 			3,
 			0,
+			7,
+			Layout{Horizontal: Smushing, HorizontalRules: SmushEqual | SmushUnderscore},
+			0,
+		},
+		{
+			"flf2a$ 8 6 14 -1 2 0 24463 102",
+			"$",
+			8,
+			6,
+			2,
+			0,
+			14,
+			Layout{
+				Horizontal:      Smushing,
+				HorizontalRules: 15,
+				Vertical:        Smushing,
+				VerticalRules:   VSmushEqual | VSmushUnderscore | VSmushHierarchy | VSmushHorizontalLine | VSmushVerticalLine,
+			},
+			102,
+		},
+		{
+			"flf2a$ 8 6 14 15 2 0 8256", // Full_Layout takes priority over Old_Layout
+			"$",
+			8,
+			6,
+			2,
+			0,
+			14,
+			Layout{Horizontal: Fitting, Vertical: Fitting},
+			0,
+		},
+		{
+			"flf2a$ 8 6 14 -1 2 0 0",
+			"$",
+			8,
+			6,
+			2,
+			0,
+			14,
+			Layout{},
+			0,
 		},
 	}
 
@@ -97,27 +161,100 @@ func TestFileLoaderHeaderParse(t *testing.T) {
 				testCase.printDirection,
 				loader.font.PrintDirection,
 			)
+			assertIntEqual(t, testCase.maxLength, loader.font.MaxLength)
+			assertIntEqual(t, testCase.codetagCount, loader.font.CodetagCount)
+			if testCase.layout != loader.font.Layout {
+				t.Errorf("want layout %+v but got %+v", testCase.layout, loader.font.Layout)
+			}
 		})
 	}
 
-	errorTestCases := []string{
-		"flf2a@ A 1 2 3 4 5",
-		"flf2a@ 1 A 2 3 4 5",
-		"flf2a@ 1 2 3 4 A 5",
-		"flf2a@ 1 2 3 4 5 A",
+	errorTestCases := []struct {
+		header string
+		err    string
+	}{
+		{"flf2a@ A 1 2 3 4 5", "line 1: bad Height 'A'"},
+		{"flf2a@ 1 A 2 3 4 5", "line 1: bad Baseline 'A'"},
+		{"flf2a@ 1 2 A 3 4 5", "line 1: bad Max_Length 'A'"},
+		{"flf2a@ 1 2 3 A 4 5", "line 1: bad Old_Layout 'A'"},
+		{"flf2a@ 1 2 3 4 A 5", "line 1: bad Comment_Lines 'A'"},
+		{"flf2a@ 1 2 3 4 5 A", "line 1: bad Print_Direction 'A'"},
+		{"flf2a@ 1 2 3 4 5 0 A", "line 1: bad Full_Layout 'A'"},
+		{"flf2a@ 1 2 3 4 5 0 0 A", "line 1: bad Codetag_Count 'A'"},
+		{"", "line 1: missing header"},
+		{"flf", "line 1: bad font signature"},
+		{"flf2a$ 6 5 20 15", "line 1: header has 5 fields but at least 6 are required"},
+		{"flf2a 6 5 20 15 3", "line 1: missing hardblank"},
+		{"flf2a$ 0 5 20 15 3", "line 1: bad Height '0'"},
+		{"flf2a$ 6 5 20 15 -3", "line 1: bad Comment_Lines '-3'"},
 	}
 	for _, errorTestCase := range errorTestCases {
-		t.Run(errorTestCase, func(t *testing.T) {
-			reader := strings.NewReader(errorTestCase)
+		t.Run(errorTestCase.header, func(t *testing.T) {
+			reader := strings.NewReader(errorTestCase.header)
 			loader, err := NewFileLoader(reader)
 			assertNoError(t, err)
 
 			err = loader.parseHeaders()
-			assertError(t, err, "strconv.Atoi: parsing \"A\": invalid syntax")
+			assertError(t, err, errorTestCase.err)
 		})
 	}
 }
 
+func TestFileLoaderParseErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		font string
+		err  ParseError
+	}{
+		{
+			"truncated comments",
+			"flf2a$ 2 1 4 0 3\ncomment",
+			ParseError{Line: 3, Reason: "unexpected end of file in comments"},
+		},
+		{
+			"truncated glyph",
+			"flf2a$ 3 1 4 0 0\n $@\n $@\n $@@\n!@\n!@",
+			ParseError{Line: 6, Glyph: true, Code: 33, Reason: "unexpected end of file after 2 of 3 rows"},
+		},
+		{
+			"row without endmark",
+			"flf2a$ 2 1 4 0 0\n $@\n\n $@@",
+			ParseError{Line: 3, Glyph: true, Code: 32, Reason: "row without endmark"},
+		},
+		{
+			"bad code tag",
+			"flf2a$ 1 1 4 0 0\n $@@\n!@@\nU+0422\nT@@",
+			ParseError{Line: 4, Reason: "bad code tag 'U+0422'"},
+		},
+		{
+			"code tag without glyph",
+			"flf2a$ 1 1 4 0 0\n $@@\n0x0422",
+			ParseError{Line: 3, Glyph: true, Code: 1058, Reason: "missing FIGcharacter after code tag"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			loader, err := NewFileLoader(strings.NewReader(testCase.font))
+			assertNoError(t, err)
+
+			_, err = loader.Parse()
+			parseErr, ok := err.(*ParseError)
+			if !ok {
+				t.Fatalf("expect parse error but got %v", err)
+			}
+			if *parseErr != testCase.err {
+				t.Errorf("want %+v but got %+v", testCase.err, *parseErr)
+			}
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	assertStringEqual(t, "line 3: bad code tag 'x'", (&ParseError{Line: 3, Reason: "bad code tag 'x'"}).Error())
+	assertStringEqual(t, "line 4: glyph 65: row without endmark", (&ParseError{Line: 4, Glyph: true, Code: 65, Reason: "row without endmark"}).Error())
+}
+
 func TestFileLoaderLetterParse(t *testing.T) {
 	testCases := []struct {
 		commentLines int
@@ -173,7 +310,7 @@ func TestFileLoaderLetterParse(t *testing.T) {
 	for idx, testCase := range testCases {
 		t.Run(fmt.Sprintf("test case: %d", idx), func(t *testing.T) {
 			reader := strings.NewReader(testCase.raw)
-			loader := FileLoader{FIGFont{}, testCase.commentLines, bufio.NewScanner(reader)}
+			loader := FileLoader{font: FIGFont{}, commentLines: testCase.commentLines, buf: bufio.NewScanner(reader)}
 			loader.font.Height = testCase.height
 			loader.font.Letters = make(map[int][]string)
 			err := loader.parseLetters()
@@ -188,24 +325,64 @@ func TestFileLoaderLetterParse(t *testing.T) {
 
 func TestParseCharCode(t *testing.T) {
 	testCases := []struct {
-		line  string
-		value int
+		line    string
+		value   int
+		comment string
 	}{
-		{"0x46 comment", 70},
-		{"0xE6", 230},
-		{"032", 26},
-		{"13", 13},
+		{"0x46 comment", 70, "comment"},
+		{"0xE6", 230, ""},
+		{"032", 26, ""},
+		{"13", 13, ""},
+		{"0", 0, ""},
+		{"-2  negative code", -2, "negative code"},
+		{"-0x10", -16, ""},
+		{"0x1F600\tGRINNING FACE", 0x1F600, "GRINNING FACE"},
+		{"2147483647", 2147483647, ""},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.line, func(t *testing.T) {
-			charCode, err := parseCharCode(testCase.line)
+			charCode, comment, err := parseCharCode(testCase.line)
 			assertNoError(t, err)
 			assertIntEqual(t, testCase.value, charCode)
+			assertStringEqual(t, testCase.comment, comment)
+		})
+	}
+}
+
+func TestParseCharCodeErrors(t *testing.T) {
+	for _, line := range []string{"x41", "0x", "-", "4294967296", "09"} {
+		t.Run(line, func(t *testing.T) {
+			_, _, err := parseCharCode(line)
+			if err == nil {
+				t.Errorf("expected error for code tag '%s'", line)
+			}
 		})
 	}
 }
 
+func TestFileLoaderRequiredCharacters(t *testing.T) {
+	raw := strings.Repeat("a@@\n", 95) + "\u00c4@@\n\u00d6@@\n\u00dc@@\n\u00e4@@\n\u00f6@@\n\u00fc@@\n\u00df@@\n" +
+		"-1  negative\n#@@\n0x7F #\nd@@"
+	loader := FileLoader{font: FIGFont{Height: 1, Letters: make(map[int][]string)}, buf: bufio.NewScanner(strings.NewReader(raw))}
+	err := loader.parseLetters()
+	assertNoError(t, err)
+
+	expected := map[int]string{
+		32: "a", 126: "a", 196: "Ä", 214: "Ö", 220: "Ü", 228: "ä", 246: "ö", 252: "ü", 223: "ß",
+		-1: "#", 127: "d",
+	}
+	for code, row := range expected {
+		if letter, ok := loader.font.Letters[code]; !ok || letter[0] != row {
+			t.Errorf("letter %d: expect %q but got %q", code, row, letter)
+		}
+	}
+	assertIntEqual(t, 95+7+2, len(loader.font.Letters))
+	if !reflect.DeepEqual(map[int]string{-1: "negative", 127: "#"}, loader.font.CodetagComments) {
+		t.Errorf("unexpected code tag comments: %v", loader.font.CodetagComments)
+	}
+}
+
 func TestFileLoader(t *testing.T) {
 	fontFile := `flf2a$ 6 4 6 -1 4
 3x5 font by Richard Kirk (rak@crosfield.co.uk).
@@ -265,6 +442,85 @@ by Daniel Cabeza Gras (bardo@dia.fi.upm.es)
 	}
 }
 
+func TestFileLoaderTLF(t *testing.T) {
+	fontFile := "tlf2a¤ 2 2 6 -1 1 0 0 0\n" +
+		"TOIlet font with UTF-8 sub-characters\n" +
+		"¤¤@\n" +
+		"¤¤@@\n" +
+		"▄█▄▀@\n" +
+		"▀█▀¤@@\n"
+
+	loader, err := NewFileLoader(strings.NewReader(fontFile))
+	assertNoError(t, err)
+	font, err := loader.Parse()
+	assertNoError(t, err)
+
+	assertStringEqual(t, "¤", font.Hardblank)
+	letters := map[int][]string{
+		32: {"¤¤", "¤¤"},
+		33: {"▄█▄▀", "▀█▀¤"},
+	}
+	if !reflect.DeepEqual(letters, font.Letters) {
+		t.Errorf("letter parsing error:\nexpect: %v\n   got: %v", letters, font.Letters)
+	}
+
+	printed, err := font.Print("! !")
+	assertNoError(t, err)
+	assertStringEqual(t, "▄█▄▀  ▄█▄▀\n▀█▀   ▀█▀ ", printed)
+}
+
+func TestFileLoaderZip(t *testing.T) {
+	fontFile := "flf2a$ 2 2 4 -1 0\n$$@\n$$@@\n|@\n|@@\n"
+
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	_, err := zipWriter.Create("fonts/")
+	assertNoError(t, err)
+	entry, err := zipWriter.Create("fonts/font.flf")
+	assertNoError(t, err)
+	_, err = entry.Write([]byte(fontFile))
+	assertNoError(t, err)
+	assertNoError(t, zipWriter.Close())
+
+	loader, err := NewFileLoader(&archive)
+	assertNoError(t, err)
+	font, err := loader.Parse()
+	assertNoError(t, err)
+
+	letters := map[int][]string{
+		32: {"$$", "$$"},
+		33: {"|", "|"},
+	}
+	if !reflect.DeepEqual(letters, font.Letters) {
+		t.Errorf("letter parsing error:\nexpect: %v\n   got: %v", letters, font.Letters)
+	}
+
+	t.Run("broken archive", func(t *testing.T) {
+		_, err := NewFileLoader(strings.NewReader(zipSignature + "broken"))
+		assertError(t, err, "zip: not a valid zip file")
+	})
+
+	t.Run("archive without files", func(t *testing.T) {
+		var archive bytes.Buffer
+		zipWriter := zip.NewWriter(&archive)
+		_, err := zipWriter.Create("fonts/")
+		assertNoError(t, err)
+		assertNoError(t, zipWriter.Close())
+
+		_, err = NewFileLoader(&archive)
+		assertError(t, err, "zip archive has no files")
+	})
+}
+
+func TestReadLimited(t *testing.T) {
+	data, err := readLimited(strings.NewReader("abcd"), 4, "font")
+	assertNoError(t, err)
+	assertStringEqual(t, "abcd", string(data))
+
+	_, err = readLimited(strings.NewReader("abcde"), 4, "font")
+	assertError(t, err, "font is larger than 4 bytes")
+}
+
 func assertNoError(t *testing.T, err error) {
 	t.Helper()
 
@@ -0,0 +1,158 @@
+package figfont
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const defaultHardblank = "$"
+
+// FileWriter is set of data to save font to file
+type FileWriter struct {
+	buf *bufio.Writer
+}
+
+// NewFileWriter creates FIG font writer to file
+func NewFileWriter(w io.Writer) (FileWriter, error) {
+	return FileWriter{buf: bufio.NewWriter(w)}, nil
+}
+
+// Write serializes font in flf2a format: header, comments, required
+// characters and code tagged characters
+func (writer *FileWriter) Write(font FIGFont) error {
+	required, tagged := splitCharCodes(font)
+	endmark := chooseEndmark(font)
+
+	writer.writeHeader(font, len(tagged), maxLineLength(font))
+	for _, code := range required {
+		writer.writeLetter(font.Letters[code], font.Height, endmark)
+	}
+	for _, code := range tagged {
+		codeTag := fmt.Sprintf("0x%04X", code)
+		if code < 0 {
+			codeTag = fmt.Sprintf("%d", code)
+		}
+		if comment := font.CodetagComments[code]; comment != "" {
+			codeTag += "  " + comment
+		}
+		fmt.Fprintln(writer.buf, codeTag)
+		writer.writeLetter(font.Letters[code], font.Height, endmark)
+	}
+
+	return writer.buf.Flush()
+}
+
+func (writer *FileWriter) writeHeader(font FIGFont, codetagCount int, maxLength int) {
+	hardblank := font.Hardblank
+	if hardblank == "" {
+		hardblank = defaultHardblank
+	}
+	if font.MaxLength > maxLength {
+		maxLength = font.MaxLength
+	}
+
+	var comment []string
+	if font.Comment != "" {
+		comment = strings.Split(font.Comment, "\n")
+	}
+
+	fmt.Fprintf(
+		writer.buf,
+		"%s%s %d %d %d %d %d %d %d %d\n",
+		fontSignature+"a",
+		hardblank,
+		font.Height,
+		font.Baseline,
+		maxLength,
+		font.Layout.OldLayout(),
+		len(comment),
+		font.PrintDirection,
+		font.Layout.FullLayout(),
+		codetagCount,
+	)
+	for _, line := range comment {
+		fmt.Fprintln(writer.buf, line)
+	}
+}
+
+// writeLetter writes rows of letter with endmarks, the last row is ended
+// by double endmark. Missing letter is written as empty FIGcharacter
+func (writer *FileWriter) writeLetter(letter []string, height int, endmark string) {
+	for row := 0; row < height; row++ {
+		var line string
+		if row < len(letter) {
+			line = letter[row]
+		}
+
+		line += endmark
+		if row == height-1 {
+			line += endmark
+		}
+		fmt.Fprintln(writer.buf, line)
+	}
+}
+
+// splitCharCodes returns codes of required characters in order of font file
+// and sorted codes of other characters which have to be code tagged.
+// Required characters are written up to the last one present in font
+func splitCharCodes(font FIGFont) (required []int, tagged []int) {
+	allRequired := requiredCharCodes()
+	isRequired := make(map[int]bool)
+	for _, code := range allRequired {
+		isRequired[code] = true
+	}
+
+	for code := range font.Letters {
+		if !isRequired[code] {
+			tagged = append(tagged, code)
+		}
+	}
+	sort.Ints(tagged)
+
+	last := len(allRequired) - 1
+	if len(tagged) == 0 {
+		// trailing missing required characters could be omitted
+		for last >= 0 && font.Letters[allRequired[last]] == nil {
+			last--
+		}
+	}
+
+	return allRequired[:last+1], tagged
+}
+
+// chooseEndmark returns endmark which doesn't end any row of letters
+// to keep rows unambiguous
+func chooseEndmark(font FIGFont) string {
+	for _, endmark := range endmarkChars {
+		conflict := false
+		for _, letter := range font.Letters {
+			for _, row := range letter {
+				if strings.HasSuffix(row, string(endmark)) {
+					conflict = true
+				}
+			}
+		}
+		if !conflict {
+			return string(endmark)
+		}
+	}
+
+	return endmarkChars[:1]
+}
+
+// maxLineLength returns length of the longest line of letters with endmarks
+func maxLineLength(font FIGFont) int {
+	maxLength := 0
+	for _, letter := range font.Letters {
+		for _, row := range letter {
+			if length := len([]rune(row)) + 2; length > maxLength {
+				maxLength = length
+			}
+		}
+	}
+
+	return maxLength
+}
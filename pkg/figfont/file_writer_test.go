@@ -0,0 +1,89 @@
+package figfont
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFileWriter(t *testing.T) {
+	font := FIGFont{
+		Hardblank:      "$",
+		Height:         2,
+		Baseline:       1,
+		PrintDirection: 1,
+		Layout:         Layout{Horizontal: Smushing, HorizontalRules: SmushEqual | SmushBigX},
+		Comment:        "test font\nsecond line",
+		Letters: map[int][]string{
+			32: {"$", "$"},
+			33: {"|", "."},
+			35: {"#@", "##"},
+		},
+	}
+
+	expected := `flf2a$ 2 1 4 17 2 1 145 0
+test font
+second line
+$%
+$%%
+|%
+.%%
+%
+%%
+#@%
+##%%
+`
+
+	var buf bytes.Buffer
+	writer, err := NewFileWriter(&buf)
+	assertNoError(t, err)
+	err = writer.Write(font)
+	assertNoError(t, err)
+	assertStringEqual(t, expected, buf.String())
+}
+
+func TestFileWriterRoundTrip(t *testing.T) {
+	font := FIGFont{
+		Hardblank:      "~",
+		Height:         3,
+		Baseline:       2,
+		MaxLength:      10,
+		CodetagCount:   2,
+		PrintDirection: 0,
+		Layout: Layout{
+			Horizontal:      Fitting,
+			HorizontalRules: SmushHierarchy,
+			Vertical:        Smushing,
+			VerticalRules:   VSmushVerticalLine,
+		},
+		Comment: "round trip",
+		Letters: map[int][]string{
+			1058: {" ____ ", "(_  _)", "  )(  "},
+			2000: {"", "", ""},
+		},
+		CodetagComments: map[int]string{1058: "CYRILLIC CAPITAL LETTER TE"},
+	}
+	font.Letters[32] = []string{"~~", "~~", "~~"}
+	font.Letters[33] = []string{"|", "|", "o"}
+	for code := 34; code <= 126; code++ {
+		font.Letters[code] = []string{string(rune(code)) + " ", " ", "."}
+	}
+	for _, code := range deutschCodes {
+		font.Letters[code] = []string{string(rune(code)), "", ""}
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewFileWriter(&buf)
+	assertNoError(t, err)
+	assertNoError(t, writer.Write(font))
+
+	loader, err := NewFileLoader(strings.NewReader(buf.String()))
+	assertNoError(t, err)
+	loaded, err := loader.Parse()
+	assertNoError(t, err)
+
+	if !reflect.DeepEqual(font, loaded) {
+		t.Errorf("fonts not match:\nexpect: %+v\n   got: %+v", font, loaded)
+	}
+}
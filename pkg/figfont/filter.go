@@ -0,0 +1,14 @@
+package figfont
+
+// Filter post-processes rendering, e.g. paints its sub-characters. Filter
+// must not modify rows and colors of passed rendering
+type Filter func(rendering Rendering) Rendering
+
+// Apply returns rendering processed by filters one after another
+func (rendering Rendering) Apply(filters ...Filter) Rendering {
+	for _, filter := range filters {
+		rendering = filter(rendering)
+	}
+
+	return rendering
+}
@@ -1,9 +1,19 @@
 package figfont
 
-import (
-	"fmt"
-	"strings"
-)
+// deutschCodes are codes of required Deutsch characters in order of font file:
+// Ä Ö Ü ä ö ü ß
+var deutschCodes = []int{196, 214, 220, 228, 246, 252, 223}
+
+// requiredCharCodes returns codes of characters which every font file
+// contains in fixed order: ASCII 32-126 and Deutsch characters
+func requiredCharCodes() []int {
+	codes := make([]int, 0, 95+len(deutschCodes))
+	for code := 32; code <= 126; code++ {
+		codes = append(codes, code)
+	}
+
+	return append(codes, deutschCodes...)
+}
 
 // FIGFont contains all data of FIG Font to able to print message
 type FIGFont struct {
@@ -12,36 +22,35 @@ type FIGFont struct {
 	Height         int              `json:"height"`
 	Baseline       int              `json:"baseline"`
 	PrintDirection int              `json:"printDirection"`
+	Layout         Layout           `json:"layout"`
+	MaxLength      int              `json:"maxLength"`
+	CodetagCount   int              `json:"codetagCount"`
+	Comment        string           `json:"comment"`
 	Letters        map[int][]string `json:"letters"`
+	// CodetagComments contains comments which follow code tags in font file
+	CodetagComments map[int]string `json:"codetagComments"`
 }
 
+// Print renders phrase with font joining FIGcharacters according to font layout.
+// Every line of phrase is rendered as separate FIG line
 func (font FIGFont) Print(phrase string) (string, error) {
-	var printed []string
-
-	if font.PrintDirection != 0 {
-		phrase = strReverse(phrase)
-	}
+	return font.PrintWithOptions(phrase, PrintOptions{})
+}
 
-	for row := 0; row < font.Height; row++ {
-		var printedRow string
-		for _, letter := range phrase {
-			data, ok := font.Letters[int(letter)]
-			if !ok {
-				return "", fmt.Errorf("unknown letter '%v'", letter)
-			}
-
-			printedRow = printedRow + strings.Replace(data[row], font.Hardblank, " ", -1)
-		}
-		printed = append(printed, printedRow)
+// baseline returns Baseline of font, wrong value is replaced by Height
+// so FIGcharacters are aligned on the bottom
+func (font FIGFont) baseline() int {
+	if font.Baseline < 1 || font.Baseline > font.Height {
+		return font.Height
 	}
 
-	return strings.Join(printed, "\n"), nil
+	return font.Baseline
 }
 
-func strReverse(str string) string {
-	reversed := []rune(str)
-	for left, right := 0, len(reversed)-1; left < right; left, right = left+1, right-1 {
-		reversed[left], reversed[right] = reversed[right], reversed[left]
+func (font FIGFont) hardblank() rune {
+	for _, char := range font.Hardblank {
+		return char
 	}
-	return string(reversed)
+
+	return 0
 }
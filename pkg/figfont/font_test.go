@@ -0,0 +1,98 @@
+package figfont
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testFont(layout Layout) FIGFont {
+	return FIGFont{
+		Hardblank: "$",
+		Height:    3,
+		Baseline:  3,
+		Layout:    layout,
+		Letters: map[int][]string{
+			' ': {"$$", "$$", "$$"},
+			'L': {"|  ", "|  ", "|__"},
+			'I': {" |", " |", " |"},
+			'a': {"a a", "a a", "a a"},
+			'b': {"b b", "b b", "b b"},
+		},
+	}
+}
+
+func TestFIGFontPrint(t *testing.T) {
+	testCases := []struct {
+		name   string
+		layout Layout
+		phrase string
+		result string
+	}{
+		{"full width", Layout{}, "LI", "|   |\n|   |\n|__ |"},
+		{"fitting", Layout{Horizontal: Fitting}, "LI", "|  |\n|  |\n|__|"},
+		{"universal smushing", Layout{Horizontal: Smushing}, "LI", "| |\n| |\n|_|"},
+		{"controlled smushing", Layout{Horizontal: Smushing, HorizontalRules: SmushUnderscore}, "LI", "| |\n| |\n|_|"},
+		{"controlled smushing without matching rule", Layout{Horizontal: Smushing, HorizontalRules: SmushEqual}, "LI", "|  |\n|  |\n|__|"},
+		{"hardblank", Layout{Horizontal: Smushing, HorizontalRules: SmushEqual}, "I I", " |  |\n |  |\n |  |"},
+		{"hardblank smushing", Layout{Horizontal: Smushing, HorizontalRules: SmushHardblank}, "I  I", " |   |\n |   |\n |   |"},
+		{"multi-line", Layout{}, "L\nI", "|  \n|  \n|__\n |\n |\n |"},
+		{"windows line breaks", Layout{}, "L\r\nI", "|  \n|  \n|__\n |\n |\n |"},
+		{"empty line", Layout{}, "I\n\nI", " |\n |\n |\n\n\n\n |\n |\n |"},
+		{"tab", Layout{Horizontal: Fitting}, "I\tI", " |              |\n |              |\n |              |"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			printed, err := testFont(testCase.layout).Print(testCase.phrase)
+			assertNoError(t, err)
+			assertStringEqual(t, testCase.result, printed)
+		})
+	}
+
+	t.Run("unknown letter", func(t *testing.T) {
+		_, err := testFont(Layout{}).Print("LX")
+		assertError(t, err, "unknown letter '88'")
+	})
+}
+
+func TestSmushChars(t *testing.T) {
+	testCases := []struct {
+		rules  SmushRule
+		left   rune
+		right  rune
+		result rune
+		ok     bool
+	}{
+		{0, 'a', 'b', 'b', true},
+		{0, '$', 'b', 'b', true},
+		{SmushEqual, ' ', 'b', 'b', true},
+		{SmushEqual, 'b', 'b', 'b', true},
+		{SmushEqual, 'a', 'b', 0, false},
+		{SmushUnderscore, '_', '/', '/', true},
+		{SmushUnderscore, '(', '_', '(', true},
+		{SmushUnderscore, '_', 'a', 0, false},
+		{SmushHierarchy, '|', '/', '/', true},
+		{SmushHierarchy, '>', '[', '>', true},
+		{SmushHierarchy, '{', '{', 0, false},
+		{SmushOppositePair, '[', ']', '|', true},
+		{SmushOppositePair, ')', '(', '|', true},
+		{SmushOppositePair, '(', '(', 0, false},
+		{SmushBigX, '/', '\\', '|', true},
+		{SmushBigX, '\\', '/', 'Y', true},
+		{SmushBigX, '>', '<', 'X', true},
+		{SmushBigX, '<', '>', 0, false},
+		{SmushHardblank, '$', '$', '$', true},
+		{SmushEqual, '$', '$', 0, false},
+	}
+
+	for _, testCase := range testCases {
+		caseName := fmt.Sprintf("%d %c%c", testCase.rules, testCase.left, testCase.right)
+		t.Run(caseName, func(t *testing.T) {
+			layout := Layout{Horizontal: Smushing, HorizontalRules: testCase.rules}
+			result, ok := smushChars(testCase.left, testCase.right, layout, '$', false)
+			if ok != testCase.ok || result != testCase.result {
+				t.Errorf("want '%c' %v but got '%c' %v", testCase.result, testCase.ok, result, ok)
+			}
+		})
+	}
+}
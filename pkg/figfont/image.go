@@ -0,0 +1,126 @@
+package figfont
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+)
+
+// ImageOptions are settings of raster image of rendering
+type ImageOptions struct {
+	// Foreground is color of sub-characters not painted by filters,
+	// black by default. Background is transparent if not set
+	Foreground color.RGBA
+	Background color.RGBA
+	// Scale is size of bitmap font pixel in image pixels, 1 by default
+	Scale int
+	// Padding is space around text in image pixels
+	Padding int
+}
+
+// Image draws rendering by built-in bitmap font. Colors set by filters
+// are kept
+func (rendering Rendering) Image(opts ImageOptions) *image.RGBA {
+	opts = opts.withDefaults()
+	cellWidth := bitmapCellWidth * opts.Scale
+	cellHeight := bitmapCellHeight * opts.Scale
+	img := image.NewRGBA(image.Rectangle{Max: rendering.ImageSize(opts)})
+	if opts.Background.A != 0 {
+		draw.Draw(img, img.Bounds(), image.NewUniform(opts.Background), image.Point{}, draw.Src)
+	}
+
+	for row, line := range rendering.Rows {
+		runes := []rune(line)
+		colors := rendering.rowColors(row)
+		for col, char := range runes {
+			if char == ' ' {
+				continue
+			}
+			fill := cellColor(runes, colors, col)
+			if fill.A == 0 {
+				fill = opts.Foreground
+			}
+			origin := image.Pt(opts.Padding+col*cellWidth, opts.Padding+row*cellHeight)
+			drawBitmapGlyph(img, origin, bitmapGlyph(char), fill, opts.Scale)
+		}
+	}
+
+	return img
+}
+
+// ImageSize returns width and height of raster image of rendering
+func (rendering Rendering) ImageSize(opts ImageOptions) image.Point {
+	opts = opts.withDefaults()
+
+	return image.Pt(
+		2*opts.Padding+rendering.Width*bitmapCellWidth*opts.Scale,
+		2*opts.Padding+len(rendering.Rows)*bitmapCellHeight*opts.Scale,
+	)
+}
+
+func (opts ImageOptions) withDefaults() ImageOptions {
+	if opts.Scale <= 0 {
+		opts.Scale = 1
+	}
+	if opts.Foreground.A == 0 {
+		opts.Foreground = color.RGBA{A: 255}
+	}
+
+	return opts
+}
+
+// PNG writes rendering drawn by built-in bitmap font as PNG image
+func (rendering Rendering) PNG(w io.Writer, opts ImageOptions) error {
+	return png.Encode(w, rendering.Image(opts))
+}
+
+// GIF writes rendering drawn by built-in bitmap font as GIF image
+func (rendering Rendering) GIF(w io.Writer, opts ImageOptions) error {
+	return gif.Encode(w, palettedImage(rendering.Image(opts)), nil)
+}
+
+func drawBitmapGlyph(img *image.RGBA, origin image.Point, glyph [bitmapGlyphHeight]string, fill color.RGBA, scale int) {
+	src := image.NewUniform(fill)
+	for y, pixels := range glyph {
+		for x := 0; x < len(pixels); x++ {
+			if pixels[x] != '#' {
+				continue
+			}
+			pixel := image.Rect(x*scale, y*scale, (x+1)*scale, (y+1)*scale).Add(origin)
+			draw.Draw(img, pixel, src, image.Point{}, draw.Src)
+		}
+	}
+}
+
+// palettedImage converts image to paletted one with exact colors if it
+// has no more than 256 colors, otherwise colors are approximated by web
+// safe palette. Transparent color is kept
+func palettedImage(img *image.RGBA) *image.Paletted {
+	var colors color.Palette
+	seen := make(map[color.RGBA]bool)
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y && len(colors) <= 256; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := img.RGBAAt(x, y)
+			if !seen[pixel] {
+				seen[pixel] = true
+				colors = append(colors, pixel)
+			}
+		}
+	}
+	if len(colors) == 0 {
+		colors = color.Palette{color.RGBA{}}
+	}
+	if len(colors) > 256 {
+		colors = append(color.Palette{color.RGBA{}}, palette.WebSafe...)
+	}
+
+	paletted := image.NewPaletted(bounds, colors)
+	draw.Draw(paletted, bounds, img, bounds.Min, draw.Src)
+
+	return paletted
+}
@@ -0,0 +1,156 @@
+package figfont
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestBitmapGlyphs(t *testing.T) {
+	var codes []rune
+	for code := rune(32); code <= 126; code++ {
+		codes = append(codes, code)
+	}
+	// box drawing characters and block elements of TOIlet fonts
+	for code := rune(0x2500); code <= 0x259f; code++ {
+		codes = append(codes, code)
+	}
+	for _, code := range codes {
+		if _, ok := bitmapGlyphs[code]; !ok {
+			t.Errorf("missing glyph %q", code)
+		}
+	}
+
+	for char, glyph := range bitmapGlyphs {
+		for _, row := range glyph {
+			if len(row) != bitmapGlyphWidth {
+				t.Errorf("glyph %q has row '%s' of width %d", char, row, len(row))
+			}
+		}
+	}
+}
+
+func TestRenderingImage(t *testing.T) {
+	white := color.RGBA{255, 255, 255, 255}
+	black := color.RGBA{0, 0, 0, 255}
+	red := color.RGBA{255, 0, 0, 255}
+	rendering := Rendering{Rows: []string{"| |", "☃"}, Width: 3, Height: 2}
+	opts := ImageOptions{Background: white, Scale: 2, Padding: 3}
+
+	testCases := []struct {
+		name      string
+		rendering Rendering
+		x, y      int
+		expected  color.RGBA
+	}{
+		{"padding", rendering, 1, 1, white},
+		{"glyph pixel", rendering, 3 + 2*2, 3 + 7*2 + 1, black},
+		{"glyph blank pixel", rendering, 3, 3, white},
+		{"space", rendering, 3 + 6*2 + 2*2, 3, white},
+		{"unknown glyph", rendering, 3, 3 + 9*2, black},
+		{"painted glyph", rendering.Apply(Gradient(red, red)), 3 + 2*12 + 2*2, 3, red},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			img := testCase.rendering.Image(opts)
+			assertIntEqual(t, 3*2+3*6*2, img.Bounds().Dx())
+			assertIntEqual(t, 3*2+2*9*2, img.Bounds().Dy())
+			if pixel := img.RGBAAt(testCase.x, testCase.y); pixel != testCase.expected {
+				t.Errorf("want %v but got %v", testCase.expected, pixel)
+			}
+		})
+	}
+}
+
+func TestRenderingImageTLF(t *testing.T) {
+	fontFile := "tlf2a¤ 3 3 3 -1 1 0 0 0\n" +
+		"TOIlet font with block and box drawing sub-characters\n" +
+		"¤@\n" +
+		"¤@\n" +
+		"¤@@\n" +
+		"┌─┐@\n" +
+		"│▌▐@\n" +
+		"└▄▀@@\n"
+
+	loader, err := NewFileLoader(strings.NewReader(fontFile))
+	assertNoError(t, err)
+	font, err := loader.Parse()
+	assertNoError(t, err)
+	rendering, err := font.Render("!", PrintOptions{})
+	assertNoError(t, err)
+
+	img := rendering.Image(ImageOptions{})
+	for row, line := range rendering.Rows {
+		for col, char := range []rune(line) {
+			glyph := bitmapGlyph(char)
+			if glyph == bitmapUnknown {
+				t.Errorf("sub-character %q is drawn as unknown", char)
+				continue
+			}
+			for y, pixels := range glyph {
+				for x := range pixels {
+					pixel := img.RGBAAt(col*bitmapCellWidth+x, row*bitmapCellHeight+y)
+					if painted := pixel.A != 0; painted != (pixels[x] == '#') {
+						t.Errorf("sub-character %q: pixel %d,%d painted %v", char, x, y, painted)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRenderingImageTransparent(t *testing.T) {
+	img := Rendering{Rows: []string{"a"}, Width: 1, Height: 1}.Image(ImageOptions{})
+	if pixel := img.RGBAAt(0, 0); pixel.A != 0 {
+		t.Errorf("background is painted by %v", pixel)
+	}
+}
+
+func TestRenderingEncodeImage(t *testing.T) {
+	rendering := Rendering{Rows: []string{"ab", "c"}, Width: 2, Height: 2}.Apply(Rainbow())
+	opts := ImageOptions{Background: color.RGBA{0, 0, 255, 255}}
+	expected := rendering.Image(opts)
+
+	testCases := []struct {
+		name   string
+		encode func(rendering Rendering, buf *bytes.Buffer) error
+		decode func(buf *bytes.Buffer) (image.Image, error)
+	}{
+		{
+			"png",
+			func(rendering Rendering, buf *bytes.Buffer) error { return rendering.PNG(buf, opts) },
+			func(buf *bytes.Buffer) (image.Image, error) { return png.Decode(buf) },
+		},
+		{
+			"gif",
+			func(rendering Rendering, buf *bytes.Buffer) error { return rendering.GIF(buf, opts) },
+			func(buf *bytes.Buffer) (image.Image, error) { return gif.Decode(buf) },
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assertNoError(t, testCase.encode(rendering, &buf))
+			img, err := testCase.decode(&buf)
+			assertNoError(t, err)
+
+			bounds := expected.Bounds()
+			if img.Bounds() != bounds {
+				t.Fatalf("want bounds %v but got %v", bounds, img.Bounds())
+			}
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				for x := bounds.Min.X; x < bounds.Max.X; x++ {
+					if pixel := color.RGBAModel.Convert(img.At(x, y)); pixel != expected.RGBAAt(x, y) {
+						t.Fatalf("pixel %d,%d: want %v but got %v", x, y, expected.RGBAAt(x, y), pixel)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package figfont
+
+// LayoutMode defines how FIGcharacters are joined together
+type LayoutMode int
+
+const (
+	// FullWidth keeps every FIGcharacter at its full designed width
+	FullWidth LayoutMode = iota
+	// Fitting moves FIGcharacters together until they touch (kerning)
+	Fitting
+	// Smushing overlaps FIGcharacters by one more sub-character than fitting.
+	// Without any rules it is universal smushing, otherwise controlled smushing
+	Smushing
+)
+
+// SmushRule is a bit of smushing rules set as it defined in FIGfont spec
+type SmushRule int
+
+// horizontal smushing rules
+const (
+	SmushEqual SmushRule = 1 << iota
+	SmushUnderscore
+	SmushHierarchy
+	SmushOppositePair
+	SmushBigX
+	SmushHardblank
+)
+
+// vertical smushing rules
+const (
+	VSmushEqual SmushRule = 256 << iota
+	VSmushUnderscore
+	VSmushHierarchy
+	VSmushHorizontalLine
+	VSmushVerticalLine
+)
+
+// layout bits of Full_Layout header field
+const (
+	horizontalRulesMask = 63
+	horizontalFitting   = 64
+	horizontalSmushing  = 128
+	verticalRulesMask   = 7936
+	verticalFitting     = 8192
+	verticalSmushing    = 16384
+)
+
+// Layout describes how FIGcharacters of font should be placed side by side
+type Layout struct {
+	Horizontal      LayoutMode `json:"horizontal"`
+	HorizontalRules SmushRule  `json:"horizontalRules"`
+	Vertical        LayoutMode `json:"vertical"`
+	VerticalRules   SmushRule  `json:"verticalRules"`
+}
+
+// newOldLayout creates layout from Old_Layout header field
+// which describes horizontal layout only
+func newOldLayout(oldLayout int) Layout {
+	switch {
+	case oldLayout < 0:
+		return Layout{Horizontal: FullWidth}
+	case oldLayout == 0:
+		return Layout{Horizontal: Fitting}
+	default:
+		return Layout{Horizontal: Smushing, HorizontalRules: SmushRule(oldLayout & horizontalRulesMask)}
+	}
+}
+
+// newFullLayout creates layout from Full_Layout header field
+func newFullLayout(fullLayout int) Layout {
+	layout := Layout{
+		HorizontalRules: SmushRule(fullLayout & horizontalRulesMask),
+		VerticalRules:   SmushRule(fullLayout & verticalRulesMask),
+	}
+
+	switch {
+	case fullLayout&horizontalSmushing != 0:
+		layout.Horizontal = Smushing
+	case fullLayout&horizontalFitting != 0:
+		layout.Horizontal = Fitting
+	}
+	switch {
+	case fullLayout&verticalSmushing != 0:
+		layout.Vertical = Smushing
+	case fullLayout&verticalFitting != 0:
+		layout.Vertical = Fitting
+	}
+
+	return layout
+}
+
+// OldLayout returns value of Old_Layout header field. Universal smushing
+// can't be described by Old_Layout so it is reported as fitting
+func (layout Layout) OldLayout() int {
+	switch {
+	case layout.Horizontal == FullWidth:
+		return -1
+	case layout.Horizontal == Smushing && layout.HorizontalRules != 0:
+		return int(layout.HorizontalRules & horizontalRulesMask)
+	default:
+		return 0
+	}
+}
+
+// FullLayout returns value of Full_Layout header field
+func (layout Layout) FullLayout() int {
+	fullLayout := int(layout.HorizontalRules&horizontalRulesMask) | int(layout.VerticalRules&verticalRulesMask)
+
+	switch layout.Horizontal {
+	case Fitting:
+		fullLayout |= horizontalFitting
+	case Smushing:
+		fullLayout |= horizontalSmushing
+	}
+	switch layout.Vertical {
+	case Fitting:
+		fullLayout |= verticalFitting
+	case Smushing:
+		fullLayout |= verticalSmushing
+	}
+
+	return fullLayout
+}
+
+// Has reports whether all bits of rule are set
+func (rules SmushRule) Has(rule SmushRule) bool {
+	return rules&rule == rule
+}
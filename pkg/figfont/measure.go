@@ -0,0 +1,43 @@
+package figfont
+
+// Size is dimensions of rendered phrase
+type Size struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Measure calculates size of phrase rendered with font according to
+// options. Only widths of FIG lines and ends of their rows are tracked,
+// rows are composed just when vertical fitting or smushing has to find
+// how many rows of adjacent lines overlap
+func (font FIGFont) Measure(phrase string, opts PrintOptions) (Size, error) {
+	p := newPrinter(font, opts)
+	p.measureOnly = font.Layout.Vertical == FullWidth
+	lines, _, err := p.figLines(phrase)
+	if err != nil {
+		return Size{}, err
+	}
+
+	var size Size
+	width := justifyWidth(lines, opts.Width)
+	justify := p.justification()
+	text := newFIGText(font.Layout)
+	for _, line := range lines {
+		shift := justifyShift(line.width(), width, justify)
+		if line.width()+shift > size.Width {
+			size.Width = line.width() + shift
+		}
+
+		if p.measureOnly {
+			size.Height += line.height()
+			continue
+		}
+		textHeight := len(text.rows)
+		text.add(shiftRows(line.output(), shift))
+		size.Height += len(text.rows) - textHeight
+		// the next line could overlap only rows of this one
+		text.rows = text.rows[len(text.rows)-line.height():]
+	}
+
+	return size, nil
+}
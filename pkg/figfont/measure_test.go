@@ -0,0 +1,63 @@
+package figfont
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFIGFontMeasure(t *testing.T) {
+	testCases := []struct {
+		name   string
+		layout Layout
+		phrase string
+		opts   PrintOptions
+		width  int
+		height int
+	}{
+		{"full width", Layout{}, "LI", PrintOptions{}, 5, 3},
+		{"smushing", Layout{Horizontal: Smushing}, "LI", PrintOptions{}, 3, 3},
+		{"multi-line", Layout{}, "LI\nI", PrintOptions{}, 5, 6},
+		{"wrapped", Layout{}, "LI LI", PrintOptions{Width: 6}, 5, 6},
+		{"justified", Layout{}, "LI\nI", PrintOptions{Justify: JustifyRight}, 5, 6},
+		{"justified within width", Layout{}, "I", PrintOptions{Width: 8, Justify: JustifyCenter}, 5, 3},
+		{"vertical smushing", Layout{Vertical: Smushing, VerticalRules: VSmushVerticalLine}, "I\nI", PrintOptions{}, 2, 3},
+		{"right to left smushing", Layout{Horizontal: Smushing}, "aLb I\nIL", PrintOptions{Direction: RightToLeft}, 9, 6},
+		{"smushing rules", Layout{Horizontal: Smushing, HorizontalRules: SmushEqual | SmushHardblank}, "a  ab I", PrintOptions{}, 15, 3},
+		{"wrapped fitting", Layout{Horizontal: Fitting}, "LI aIb LI", PrintOptions{Width: 7, Justify: JustifyCenter}, 7, 9},
+		{"vertical fitting", Layout{Horizontal: Smushing, Vertical: Fitting}, "L\nI\nLI", PrintOptions{Justify: JustifyRight}, 3, 9},
+		{"empty", Layout{}, "", PrintOptions{}, 0, 3},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			font := testFont(testCase.layout)
+			size, err := font.Measure(testCase.phrase, testCase.opts)
+			assertNoError(t, err)
+			assertIntEqual(t, testCase.width, size.Width)
+			assertIntEqual(t, testCase.height, size.Height)
+
+			rendering, err := font.Render(testCase.phrase, testCase.opts)
+			assertNoError(t, err)
+			assertIntEqual(t, rendering.Width, size.Width)
+			assertIntEqual(t, rendering.Height, size.Height)
+		})
+	}
+
+	t.Run("unknown letter", func(t *testing.T) {
+		_, err := testFont(Layout{}).Measure("x", PrintOptions{})
+		assertError(t, err, "unknown letter '120'")
+	})
+}
+
+func BenchmarkMeasure(b *testing.B) {
+	font := benchmarkFont()
+	font.Layout.Vertical = FullWidth
+	text := strings.Repeat(benchmarkLine+"\n", 500)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := font.Measure(text, PrintOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
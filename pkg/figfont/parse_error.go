@@ -0,0 +1,22 @@
+package figfont
+
+import "fmt"
+
+// ParseError describes malformed data of font file
+type ParseError struct {
+	// Line is number of line of font file starting from 1
+	Line int `json:"line"`
+	// Glyph reports whether error is related to FIGcharacter with Code
+	Glyph bool `json:"glyph"`
+	// Code is character code of malformed FIGcharacter
+	Code   int    `json:"code"`
+	Reason string `json:"reason"`
+}
+
+func (err *ParseError) Error() string {
+	if err.Glyph {
+		return fmt.Sprintf("line %d: glyph %d: %s", err.Line, err.Code, err.Reason)
+	}
+
+	return fmt.Sprintf("line %d: %s", err.Line, err.Reason)
+}
@@ -0,0 +1,43 @@
+package figfont
+
+const tabWidth = 8
+
+// phraseChars converts phrase to characters applying control files
+func phraseChars(phrase string, controls []ControlFile) []rune {
+	if len(controls) == 0 {
+		return []rune(phrase)
+	}
+
+	return applyControlFiles(phrase, controls)
+}
+
+// splitLines normalizes line breaks of phrase, expands tabs and returns
+// separate lines of phrase with indexes of their characters in phrase
+func splitLines(phrase []rune) (lines [][]rune, indexes [][]int) {
+	var line []rune
+	var lineIndexes []int
+	for idx := 0; idx < len(phrase); idx++ {
+		switch phrase[idx] {
+		case '\r':
+			if idx+1 < len(phrase) && phrase[idx+1] == '\n' {
+				idx++
+			}
+			fallthrough
+		case '\n':
+			lines = append(lines, line)
+			indexes = append(indexes, lineIndexes)
+			line, lineIndexes = nil, nil
+		case '\t':
+			spaces := tabWidth - len(line)%tabWidth
+			for i := 0; i < spaces; i++ {
+				line = append(line, ' ')
+				lineIndexes = append(lineIndexes, idx)
+			}
+		default:
+			line = append(line, phrase[idx])
+			lineIndexes = append(lineIndexes, idx)
+		}
+	}
+
+	return append(lines, line), append(indexes, lineIndexes)
+}
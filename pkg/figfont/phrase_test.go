@@ -0,0 +1,42 @@
+package figfont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitLines(t *testing.T) {
+	testCases := []struct {
+		phrase string
+		lines  []string
+	}{
+		{"", []string{""}},
+		{"abc", []string{"abc"}},
+		{"a\nb", []string{"a", "b"}},
+		{"a\r\nb\rc\n", []string{"a", "b", "c", ""}},
+		{"\tab", []string{"        ab"}},
+		{"ab\tc\td", []string{"ab      c       d"}},
+		{"ab\n\tc", []string{"ab", "        c"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.phrase, func(t *testing.T) {
+			var lines []string
+			splitted, _ := splitLines([]rune(testCase.phrase))
+			for _, line := range splitted {
+				lines = append(lines, string(line))
+			}
+			if !reflect.DeepEqual(testCase.lines, lines) {
+				t.Errorf("want %q but got %q", testCase.lines, lines)
+			}
+		})
+	}
+}
+
+func TestSplitLinesIndexes(t *testing.T) {
+	_, indexes := splitLines([]rune("a\tb\r\nc"))
+	expected := [][]int{{0, 1, 1, 1, 1, 1, 1, 1, 2}, {5}}
+	if !reflect.DeepEqual(expected, indexes) {
+		t.Errorf("want %v but got %v", expected, indexes)
+	}
+}
@@ -0,0 +1,480 @@
+package figfont
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Justification defines alignment of FIG lines
+type Justification int
+
+const (
+	// JustifyAuto aligns lines according to print direction
+	JustifyAuto Justification = iota
+	JustifyLeft
+	JustifyCenter
+	JustifyRight
+)
+
+// Direction defines order in which FIGcharacters are placed in line
+type Direction int
+
+const (
+	// DirectionAuto uses print direction of font
+	DirectionAuto Direction = iota
+	LeftToRight
+	RightToLeft
+)
+
+// MissingGlyph defines handling of characters which are absent in font
+type MissingGlyph int
+
+const (
+	// MissingError fails rendering
+	MissingError MissingGlyph = iota
+	// MissingSkip omits character
+	MissingSkip
+	// MissingPlaceholder replaces character by placeholder character
+	MissingPlaceholder
+	// MissingZeroGlyph replaces character by FIGcharacter with code 0 which
+	// is missing character glyph of FIGfont specification. Character is
+	// omitted if font has no such glyph
+	MissingZeroGlyph
+)
+
+const defaultPlaceholder = '?'
+
+// PrintOptions contains settings of phrase rendering
+type PrintOptions struct {
+	// Width limits width of rendered lines, zero means unlimited width.
+	// Lines are wrapped at word boundaries and words wider than
+	// Width are wrapped at character boundary
+	Width int
+	// Justify aligns lines within Width or within the widest line
+	// if Width is not set
+	Justify Justification
+	// Direction overrides print direction of font
+	Direction Direction
+	// ControlFiles decode and translate characters of phrase, control
+	// files are applied one after another
+	ControlFiles []ControlFile
+	// Fallbacks provide characters which are absent in font, every
+	// character is taken from the first font which has it. FIGcharacters
+	// of fonts with different heights are aligned on baseline
+	Fallbacks []FIGFont
+	// Transliterate replaces characters which are absent in fonts by
+	// the same letters in other case or by their ASCII transliteration
+	Transliterate bool
+	// Missing chooses handling of characters which are absent in font
+	// and all fallback fonts
+	Missing MissingGlyph
+	// Placeholder replaces missing characters with MissingPlaceholder,
+	// question mark is used by default
+	Placeholder rune
+}
+
+// figChar is a character of phrase with its FIGcharacter, index of font
+// which it is taken from and index of character in phrase
+type figChar struct {
+	char  rune
+	glyph [][]rune
+	font  int
+	index int
+}
+
+// renderedLine is justified FIG line with positions of its FIGcharacters
+type renderedLine struct {
+	rows  [][]rune
+	spans []GlyphSpan
+	// baseline is row of line which baseline of fonts is aligned on
+	baseline int
+}
+
+// printer renders phrase with font according to print options
+type printer struct {
+	font FIGFont
+	// fonts are printed font followed by fallback fonts
+	fonts       []FIGFont
+	opts        PrintOptions
+	hardblank   rune
+	rightToLeft bool
+	// height of FIG lines which grows when fallback fonts are aligned
+	height int
+	// measureOnly builds FIG lines without rows
+	measureOnly bool
+	// glyphs caches converted FIGcharacters, they are never modified
+	glyphs map[glyphKey][][]rune
+}
+
+// glyphKey identifies FIGcharacter by index of font and character code
+type glyphKey struct {
+	font int
+	code int
+}
+
+func newPrinter(font FIGFont, opts PrintOptions) printer {
+	rightToLeft := font.PrintDirection != 0
+	switch opts.Direction {
+	case LeftToRight:
+		rightToLeft = false
+	case RightToLeft:
+		rightToLeft = true
+	}
+
+	return printer{
+		font:        font,
+		fonts:       append([]FIGFont{font}, opts.Fallbacks...),
+		opts:        opts,
+		hardblank:   font.hardblank(),
+		rightToLeft: rightToLeft,
+		height:      font.Height,
+		glyphs:      make(map[glyphKey][][]rune),
+	}
+}
+
+// PrintWithOptions renders phrase with font according to options
+func (font FIGFont) PrintWithOptions(phrase string, opts PrintOptions) (string, error) {
+	rendering, err := font.Render(phrase, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return rendering.String(), nil
+}
+
+// lines renders phrase to justified FIG lines
+func (p printer) lines(phrase string) ([]renderedLine, error) {
+	var output []renderedLine
+	err := p.eachLine(phrase, func(line renderedLine) {
+		output = append(output, line)
+	})
+
+	return output, err
+}
+
+// eachLine renders phrase to justified FIG lines and passes them to emit
+// as soon as they are wrapped. Lines justified within the widest line
+// are collected before the first one is emitted. Nothing is emitted if
+// phrase could not be rendered
+func (p printer) eachLine(phrase string, emit func(line renderedLine)) error {
+	justify := p.justification()
+	render := func(line *figLine, width, baseline int) {
+		shift := justifyShift(line.width(), width, justify)
+		emit(renderedLine{
+			rows:     shiftRows(line.output(), shift),
+			spans:    line.glyphSpans(shift),
+			baseline: baseline - 1,
+		})
+	}
+
+	if p.opts.Width > 0 || justify == JustifyLeft {
+		return p.eachFIGLine(phrase, func(line *figLine, baseline int) {
+			render(line, p.opts.Width, baseline)
+		})
+	}
+
+	lines, baseline, err := p.figLines(phrase)
+	if err != nil {
+		return err
+	}
+	width := justifyWidth(lines, 0)
+	for _, line := range lines {
+		render(line, width, baseline)
+	}
+
+	return nil
+}
+
+// figLines splits phrase to FIG lines which fit into width. It returns
+// baseline of lines as well
+func (p printer) figLines(phrase string) ([]*figLine, int, error) {
+	var lines []*figLine
+	var baseline int
+	err := p.eachFIGLine(phrase, func(line *figLine, lineBaseline int) {
+		lines = append(lines, line)
+		baseline = lineBaseline
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return lines, baseline, nil
+}
+
+// eachFIGLine splits phrase to FIG lines which fit into width and passes
+// them with their baseline to emit one by one. Characters of the whole
+// phrase are looked up before the first line is emitted
+func (p printer) eachFIGLine(phrase string, emit func(line *figLine, baseline int)) error {
+	var phraseLines [][]figChar
+	splitted, indexes := splitLines(phraseChars(phrase, p.opts.ControlFiles))
+	for idx, phraseLine := range splitted {
+		chars, err := p.figChars(phraseLine, indexes[idx])
+		if err != nil {
+			return err
+		}
+		phraseLines = append(phraseLines, chars)
+	}
+	var baseline int
+	p.height, baseline = p.alignBaselines(phraseLines)
+
+	for _, chars := range phraseLines {
+		p.wrapLine(chars, func(line *figLine) {
+			emit(line, baseline)
+		})
+	}
+
+	return nil
+}
+
+// figChars looks up FIGcharacters of all characters of phrase
+func (p printer) figChars(phrase []rune, indexes []int) ([]figChar, error) {
+	chars := make([]figChar, 0, len(phrase))
+	for idx, letter := range phrase {
+		char, ok := p.lookup(letter, int(letter))
+		if !ok && p.opts.Transliterate {
+			if substitutes, ok := p.transliterate(letter); ok {
+				for _, substitute := range substitutes {
+					substitute.index = indexes[idx]
+					chars = append(chars, substitute)
+				}
+				continue
+			}
+		}
+		if !ok {
+			switch p.opts.Missing {
+			case MissingSkip:
+				continue
+			case MissingPlaceholder:
+				placeholder := p.opts.Placeholder
+				if placeholder == 0 {
+					placeholder = defaultPlaceholder
+				}
+				if char, ok = p.lookup(letter, int(placeholder)); !ok {
+					return nil, fmt.Errorf("unknown placeholder letter '%v'", placeholder)
+				}
+			case MissingZeroGlyph:
+				if char, ok = p.lookup(letter, 0); !ok {
+					continue
+				}
+			default:
+				return nil, fmt.Errorf("unknown letter '%v'", letter)
+			}
+		}
+
+		char.index = indexes[idx]
+		chars = append(chars, char)
+	}
+
+	return chars, nil
+}
+
+// lookup takes FIGcharacter with code from the first font which has it
+// for letter of phrase.
+// Hardblanks of fallback fonts are replaced by hardblank of printed font
+func (p printer) lookup(letter rune, code int) (figChar, bool) {
+	for idx, font := range p.fonts {
+		key := glyphKey{idx, code}
+		if glyph, ok := p.glyphs[key]; ok {
+			return figChar{char: letter, glyph: glyph, font: idx}, true
+		}
+		data, ok := font.Letters[code]
+		if !ok {
+			continue
+		}
+
+		glyph := normalizeGlyph(data, font.Height)
+		if hardblank := font.hardblank(); idx > 0 && hardblank != p.hardblank {
+			for _, row := range glyph {
+				for col, char := range row {
+					if char == hardblank {
+						row[col] = p.hardblank
+					}
+				}
+			}
+		}
+
+		p.glyphs[key] = glyph
+
+		return figChar{char: letter, glyph: glyph, font: idx}, true
+	}
+
+	return figChar{}, false
+}
+
+// alignBaselines pads FIGcharacters of fonts used in phrase with blank rows
+// to put their baselines on the same row. It returns the common height
+// and baseline
+func (p printer) alignBaselines(lines [][]figChar) (int, int) {
+	used := map[int]bool{0: true}
+	for _, chars := range lines {
+		for _, char := range chars {
+			used[char.font] = true
+		}
+	}
+	if len(used) == 1 {
+		return p.font.Height, p.font.baseline()
+	}
+
+	above, below := 0, 0
+	for idx := range used {
+		font := p.fonts[idx]
+		if baseline := font.baseline(); baseline > above {
+			above = baseline
+		}
+		if descent := font.Height - font.baseline(); descent > below {
+			below = descent
+		}
+	}
+
+	for _, chars := range lines {
+		for idx, char := range chars {
+			font := p.fonts[char.font]
+			chars[idx].glyph = padGlyph(char.glyph, above-font.baseline(), below-font.Height+font.baseline())
+		}
+	}
+
+	return above + below, above
+}
+
+// wrapLine splits characters into FIG lines which fit into width and
+// passes every line to emit as soon as it is completed
+func (p printer) wrapLine(chars []figChar, emit func(line *figLine)) {
+	if p.opts.Width <= 0 {
+		emit(p.buildLine(chars))
+		return
+	}
+
+	var lineChars []figChar
+
+	line := p.newLine()
+	for idx := 0; idx < len(chars); idx++ {
+		char := chars[idx]
+		if len(lineChars) == 0 || line.fits(char.glyph, p.opts.Width) {
+			line.addChar(char)
+			lineChars = append(lineChars, char)
+			continue
+		}
+
+		if char.char == ' ' {
+			// line break replaces spaces between words
+			emit(p.buildLine(trimSpaces(lineChars)))
+			for idx+1 < len(chars) && chars[idx+1].char == ' ' {
+				idx++
+			}
+			lineChars, line = nil, p.newLine()
+			continue
+		}
+
+		if wordStart := lastWordStart(lineChars); wordStart > 0 {
+			// move the last word to the next line
+			emit(p.buildLine(trimSpaces(lineChars[:wordStart])))
+			lineChars = append([]figChar(nil), lineChars[wordStart:]...)
+		} else {
+			// the word is wider than width so it is wrapped inside
+			emit(p.buildLine(lineChars))
+			lineChars = nil
+		}
+		line = p.buildLine(lineChars)
+		idx--
+	}
+
+	emit(line)
+}
+
+func (p printer) newLine() *figLine {
+	if p.measureOnly {
+		return newMeasuredLine(p.height, p.font.Layout, p.hardblank, p.rightToLeft)
+	}
+
+	return newFIGLine(p.height, p.font.Layout, p.hardblank, p.rightToLeft)
+}
+
+// buildLine assembles FIG line from characters
+func (p printer) buildLine(chars []figChar) *figLine {
+	line := p.newLine()
+	for _, char := range chars {
+		line.addChar(char)
+	}
+
+	return line
+}
+
+// justification resolves automatic justification by print direction
+func (p printer) justification() Justification {
+	if p.opts.Justify != JustifyAuto {
+		return p.opts.Justify
+	}
+	if p.rightToLeft {
+		return JustifyRight
+	}
+
+	return JustifyLeft
+}
+
+// justifyWidth returns width which lines are justified within, it is
+// the widest line if width is not set
+func justifyWidth(lines []*figLine, width int) int {
+	if width > 0 {
+		return width
+	}
+	for _, line := range lines {
+		if line.width() > width {
+			width = line.width()
+		}
+	}
+
+	return width
+}
+
+// justifyShift calculates shift of FIG line to align it within width
+func justifyShift(lineWidth, width int, justify Justification) int {
+	shift := width - lineWidth
+	if justify == JustifyCenter {
+		shift /= 2
+	}
+	if shift <= 0 || justify == JustifyLeft {
+		return 0
+	}
+
+	return shift
+}
+
+// shiftRows pads rows with spaces on the left
+func shiftRows(rows [][]rune, shift int) [][]rune {
+	if shift <= 0 {
+		return rows
+	}
+
+	padding := []rune(strings.Repeat(" ", shift))
+	for idx, row := range rows {
+		rows[idx] = append(append([]rune(nil), padding...), row...)
+	}
+
+	return rows
+}
+
+// lastWordStart returns index of the first character of the last word
+// which is preceded by space and non-space characters, or zero if there is
+// no such word. The word could start right after the end of chars
+func lastWordStart(chars []figChar) int {
+	for idx := len(chars); idx > 0; idx-- {
+		if chars[idx-1].char == ' ' && (idx == len(chars) || chars[idx].char != ' ') {
+			if len(trimSpaces(chars[:idx])) > 0 {
+				return idx
+			}
+			return 0
+		}
+	}
+
+	return 0
+}
+
+// trimSpaces removes trailing spaces
+func trimSpaces(chars []figChar) []figChar {
+	end := len(chars)
+	for end > 0 && chars[end-1].char == ' ' {
+		end--
+	}
+
+	return chars[:end]
+}
@@ -0,0 +1,168 @@
+package figfont
+
+import (
+	"testing"
+)
+
+func TestFIGFontPrintWithOptionsWidth(t *testing.T) {
+	testCases := []struct {
+		name   string
+		phrase string
+		width  int
+		result string
+	}{
+		{"unlimited", "LI LI", 0, "|   |  |   |\n|   |  |   |\n|__ |  |__ |"},
+		{"fits", "LI", 5, "|   |\n|   |\n|__ |"},
+		{"break at space", "LI LI", 6, "|   |\n|   |\n|__ |\n|   |\n|   |\n|__ |"},
+		{"break at several spaces", "LI   LI", 6, "|   |\n|   |\n|__ |\n|   |\n|   |\n|__ |"},
+		{"move word to next line", "I IL", 7, " |\n |\n |\n ||  \n ||  \n ||__"},
+		{"break after space", "I L", 6, " |\n |\n |\n|  \n|  \n|__"},
+		{"break inside word", "LLL", 7, "|  |  \n|  |  \n|__|__\n|  \n|  \n|__"},
+		{"glyph wider than width", "LL", 2, "|  \n|  \n|__\n|  \n|  \n|__"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts := PrintOptions{Width: testCase.width}
+			printed, err := testFont(Layout{}).PrintWithOptions(testCase.phrase, opts)
+			assertNoError(t, err)
+			assertStringEqual(t, testCase.result, printed)
+		})
+	}
+}
+
+func TestFIGFontPrintWithOptionsJustify(t *testing.T) {
+	testCases := []struct {
+		name           string
+		justify        Justification
+		width          int
+		printDirection int
+		result         string
+	}{
+		{"auto", JustifyAuto, 0, 0, "|  \n|  \n|__\n |\n |\n |"},
+		{"auto right-to-left", JustifyAuto, 0, 1, "|  \n|  \n|__\n  |\n  |\n  |"},
+		{"left", JustifyLeft, 7, 1, "|  \n|  \n|__\n |\n |\n |"},
+		{"center", JustifyCenter, 7, 0, "  |  \n  |  \n  |__\n   |\n   |\n   |"},
+		{"right", JustifyRight, 7, 0, "    |  \n    |  \n    |__\n      |\n      |\n      |"},
+		{"right within the widest line", JustifyRight, 0, 0, "|  \n|  \n|__\n  |\n  |\n  |"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			font := testFont(Layout{})
+			font.PrintDirection = testCase.printDirection
+			opts := PrintOptions{Width: testCase.width, Justify: testCase.justify}
+			printed, err := font.PrintWithOptions("L\nI", opts)
+			assertNoError(t, err)
+			assertStringEqual(t, testCase.result, printed)
+		})
+	}
+}
+
+func TestFIGFontPrintWithOptionsDirection(t *testing.T) {
+	testCases := []struct {
+		name           string
+		layout         Layout
+		printDirection int
+		direction      Direction
+		phrase         string
+		width          int
+		result         string
+	}{
+		{"font direction", Layout{}, 1, DirectionAuto, "LI", 0, " ||  \n ||  \n ||__"},
+		{"override to left-to-right", Layout{}, 1, LeftToRight, "LI", 0, "|   |\n|   |\n|__ |"},
+		{"override to right-to-left", Layout{}, 0, RightToLeft, "LI", 0, " ||  \n ||  \n ||__"},
+		{"fitting", Layout{Horizontal: Fitting}, 0, RightToLeft, "IL", 0, "|  |\n|  |\n|__|"},
+		{"universal smushing", Layout{Horizontal: Smushing}, 0, LeftToRight, "ab", 0, "a b b\na b b\na b b"},
+		{"universal smushing right-to-left", Layout{Horizontal: Smushing}, 0, RightToLeft, "ab", 0, "b b a\nb b a\nb b a"},
+		{"wrapping", Layout{}, 0, RightToLeft, "LI LI", 6, "  ||  \n  ||  \n  ||__\n  ||  \n  ||  \n  ||__"},
+		{"words order", Layout{}, 0, RightToLeft, "I L", 0, "|     |\n|     |\n|__   |"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			font := testFont(testCase.layout)
+			font.PrintDirection = testCase.printDirection
+			opts := PrintOptions{Width: testCase.width, Direction: testCase.direction}
+			printed, err := font.PrintWithOptions(testCase.phrase, opts)
+			assertNoError(t, err)
+			assertStringEqual(t, testCase.result, printed)
+		})
+	}
+}
+
+func TestFIGFontPrintWithOptionsMissing(t *testing.T) {
+	testCases := []struct {
+		name        string
+		missing     MissingGlyph
+		placeholder rune
+		zeroGlyph   bool
+		result      string
+		err         string
+	}{
+		{"error", MissingError, 0, false, "", "unknown letter '120'"},
+		{"skip", MissingSkip, 0, false, "|   |\n|   |\n|__ |", ""},
+		{"placeholder", MissingPlaceholder, 'a', false, "|  a a |\n|  a a |\n|__a a |", ""},
+		{"default placeholder is absent", MissingPlaceholder, 0, false, "", "unknown placeholder letter '63'"},
+		{"zero glyph", MissingZeroGlyph, 0, true, "|  0 |\n|  0 |\n|__0 |", ""},
+		{"zero glyph is absent", MissingZeroGlyph, 0, false, "|   |\n|   |\n|__ |", ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			font := testFont(Layout{})
+			if testCase.zeroGlyph {
+				font.Letters[0] = []string{"0", "0", "0"}
+			}
+			opts := PrintOptions{Missing: testCase.missing, Placeholder: testCase.placeholder}
+			printed, err := font.PrintWithOptions("LxI", opts)
+			if testCase.err != "" {
+				assertError(t, err, testCase.err)
+				return
+			}
+			assertNoError(t, err)
+			assertStringEqual(t, testCase.result, printed)
+		})
+	}
+}
+
+func TestFIGFontPrintWithOptionsFallbacks(t *testing.T) {
+	small := FIGFont{
+		Hardblank: "#",
+		Height:    2,
+		Baseline:  2,
+		Letters: map[int][]string{
+			'x': {"x#", "xx"},
+		},
+	}
+	descending := FIGFont{
+		Hardblank: "$",
+		Height:    4,
+		Baseline:  2,
+		Letters: map[int][]string{
+			'x': {"X", "X", "X", "X"},
+			'y': {"y", "y", "y", "y"},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		phrase    string
+		fallbacks []FIGFont
+		result    string
+	}{
+		{"shorter font is aligned on baseline", "LxI", []FIGFont{small}, "|     |\n|  x  |\n|__xx |"},
+		{"the first font having character wins", "xI", []FIGFont{small, descending}, "   |\nx  |\nxx |"},
+		{"descender extends line", "Ly", []FIGFont{small, descending}, "|   \n|  y\n|__y\n   y\n   y"},
+		{"all lines are aligned", "I\ny", []FIGFont{descending}, " |\n |\n |\n  \n  \n \ny\ny\ny\ny"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts := PrintOptions{Fallbacks: testCase.fallbacks}
+			printed, err := testFont(Layout{}).PrintWithOptions(testCase.phrase, opts)
+			assertNoError(t, err)
+			assertStringEqual(t, testCase.result, printed)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package figfont
+
+import (
+	"bufio"
+	"io"
+)
+
+// Renderer writes FIG lines of phrases to writer as soon as they are
+// wrapped, so rows of the whole phrase are never held in memory. Lines of
+// all phrases are stacked one below another like lines of a single
+// phrase, only the last FIG line is held back until the next phrase or
+// Flush because it could be overlapped by vertical smushing.
+// Lines are justified within the widest line of every phrase if Width
+// is not set, such lines are written when the whole phrase is wrapped
+type Renderer struct {
+	printer printer
+	buf     *bufio.Writer
+	text    *figText
+	written bool
+}
+
+// NewRenderer creates renderer of phrases with font according to options
+func NewRenderer(w io.Writer, font FIGFont, opts PrintOptions) *Renderer {
+	return &Renderer{
+		printer: newPrinter(font, opts),
+		buf:     bufio.NewWriter(w),
+		text:    newFIGText(font.Layout),
+	}
+}
+
+// Render renders phrase and writes every FIG line as soon as it is
+// completed. Nothing is written if phrase could not be rendered
+func (r *Renderer) Render(phrase string) error {
+	var writeErr error
+	err := r.printer.eachLine(phrase, func(line renderedLine) {
+		r.text.add(line.rows)
+		r.writeRows(len(r.text.rows) - len(line.rows))
+		if writeErr == nil {
+			writeErr = r.buf.Flush()
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeErr
+}
+
+// Flush writes rows held back for vertical smushing
+func (r *Renderer) Flush() error {
+	r.writeRows(len(r.text.rows))
+
+	return r.buf.Flush()
+}
+
+// writeRows writes first count rows of text and removes them, rows are
+// separated by line breaks
+func (r *Renderer) writeRows(count int) {
+	if count <= 0 {
+		return
+	}
+
+	for _, row := range r.text.rows[:count] {
+		if r.written {
+			r.buf.WriteByte('\n')
+		}
+		for _, char := range row {
+			r.buf.WriteRune(char)
+		}
+		r.written = true
+	}
+	r.text.rows = append(r.text.rows[:0], r.text.rows[count:]...)
+}
@@ -0,0 +1,173 @@
+package figfont
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	renderer := NewRenderer(&buf, testFont(Layout{}), PrintOptions{})
+
+	assertNoError(t, renderer.Render("L"))
+	assertStringEqual(t, "", buf.String())
+
+	assertNoError(t, renderer.Render("I\nL"))
+	assertStringEqual(t, "|  \n|  \n|__\n |\n |\n |", buf.String())
+
+	err := renderer.Render("x")
+	assertError(t, err, "unknown letter '120'")
+
+	assertNoError(t, renderer.Flush())
+	assertStringEqual(t, "|  \n|  \n|__\n |\n |\n |\n|  \n|  \n|__", buf.String())
+}
+
+// writesRecorder keeps every write separately
+type writesRecorder struct {
+	writes []string
+}
+
+func (w *writesRecorder) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+
+	return len(p), nil
+}
+
+func TestRendererWritesLines(t *testing.T) {
+	testCases := []struct {
+		name   string
+		phrase string
+		opts   PrintOptions
+	}{
+		{"phrase lines", "L\nI\nL", PrintOptions{}},
+		{"wrapped words", "L I L", PrintOptions{Width: 3}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var recorder writesRecorder
+			renderer := NewRenderer(&recorder, testFont(Layout{}), testCase.opts)
+
+			assertNoError(t, renderer.Render(testCase.phrase))
+			expected := []string{"|  \n|  \n|__", "\n |\n |\n |"}
+			if !reflect.DeepEqual(expected, recorder.writes) {
+				t.Errorf("want writes %q but got %q", expected, recorder.writes)
+			}
+		})
+	}
+}
+
+func TestRendererVerticalSmushing(t *testing.T) {
+	var buf bytes.Buffer
+	layout := Layout{Vertical: Smushing, VerticalRules: VSmushVerticalLine}
+	renderer := NewRenderer(&buf, testFont(layout), PrintOptions{})
+
+	assertNoError(t, renderer.Render("I"))
+	assertNoError(t, renderer.Render("I"))
+	assertNoError(t, renderer.Flush())
+
+	printed, err := testFont(layout).Print("I\nI")
+	assertNoError(t, err)
+	assertStringEqual(t, printed, buf.String())
+}
+
+// benchmarkFont has all printable ASCII characters
+func benchmarkFont() FIGFont {
+	font := FIGFont{
+		Hardblank: "$",
+		Height:    4,
+		Baseline:  3,
+		Layout:    Layout{Horizontal: Smushing, Vertical: Smushing},
+		Letters:   map[int][]string{' ': {"$$", "$$", "$$", "$$"}},
+	}
+	for code := 33; code <= 126; code++ {
+		char := string(rune(code))
+		font.Letters[code] = []string{" _" + char + " ", "| " + char + "|", "|_" + char + "|", "    "}
+	}
+
+	return font
+}
+
+const benchmarkLine = "The quick brown fox jumps over the lazy dog. 0123456789"
+
+// concatPrint prints phrase line by line by concatenation of FIGcharacter
+// rows as Print did before layouts, it is the baseline of benchmarks
+func concatPrint(font FIGFont, phrase string) (string, error) {
+	var printed []string
+	for _, line := range strings.Split(phrase, "\n") {
+		for row := 0; row < font.Height; row++ {
+			var printedRow string
+			for _, letter := range line {
+				data, ok := font.Letters[int(letter)]
+				if !ok {
+					return "", fmt.Errorf("unknown letter '%v'", letter)
+				}
+
+				printedRow = printedRow + strings.Replace(data[row], font.Hardblank, " ", -1)
+			}
+			printed = append(printed, printedRow)
+		}
+	}
+
+	return strings.Join(printed, "\n"), nil
+}
+
+// benchmarkTexts are many short lines and a single long line, cost of
+// concatenation grows quadratically with length of line
+var benchmarkTexts = []struct {
+	name string
+	text string
+}{
+	{"short lines", strings.Repeat(benchmarkLine+"\n", 500)},
+	{"long line", strings.Repeat(benchmarkLine+" ", 100)},
+}
+
+func BenchmarkConcatPrint(b *testing.B) {
+	font := benchmarkFont()
+	for _, benchmark := range benchmarkTexts {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := concatPrint(font, benchmark.text); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPrint(b *testing.B) {
+	font := benchmarkFont()
+	for _, benchmark := range benchmarkTexts {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := font.Print(benchmark.text); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRenderer(b *testing.B) {
+	font := benchmarkFont()
+	for _, benchmark := range benchmarkTexts {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				renderer := NewRenderer(ioutil.Discard, font, PrintOptions{})
+				if err := renderer.Render(benchmark.text); err != nil {
+					b.Fatal(err)
+				}
+				if err := renderer.Flush(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package figfont
+
+import (
+	"image/color"
+	"strings"
+)
+
+// GlyphSpan is position of FIGcharacter of phrase character in rendering
+type GlyphSpan struct {
+	// Index is position of character in phrase counted in runes. Tab is
+	// expanded to several spaces with the same index, characters decoded
+	// by control files are counted after decoding
+	Index int  `json:"index"`
+	Rune  rune `json:"rune"`
+	// Line is number of FIG line starting from 0, Row is the top row
+	// of FIG line and Baseline is row of its baseline
+	Line     int `json:"line"`
+	Row      int `json:"row"`
+	Baseline int `json:"baseline"`
+	// Start and End are columns occupied by FIGcharacter, End is exclusive.
+	// Spans of smushed FIGcharacters overlap
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Rendering is phrase rendered with font
+type Rendering struct {
+	Rows []string `json:"rows"`
+	// Width is width of the widest row
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// Baseline is row of baseline of the first FIG line
+	Baseline int `json:"baseline"`
+	// Spans are positions of rendered characters of phrase. Missing
+	// characters which are skipped and spaces replaced by line breaks
+	// have no span, transliterated character could have several ones
+	Spans []GlyphSpan `json:"spans"`
+	// Colors are colors of sub-characters set by filters, they are
+	// indexed by row and column in runes. Transparent cells are not painted
+	Colors [][]color.RGBA `json:"-"`
+}
+
+// Render renders phrase with font according to options
+func (font FIGFont) Render(phrase string, opts PrintOptions) (Rendering, error) {
+	lines, err := newPrinter(font, opts).lines(phrase)
+	if err != nil {
+		return Rendering{}, err
+	}
+
+	var rendering Rendering
+	text := newFIGText(font.Layout)
+	for idx, line := range lines {
+		row := text.add(line.rows)
+		if idx == 0 {
+			rendering.Baseline = row + line.baseline
+		}
+		for _, span := range line.spans {
+			span.Line = idx
+			span.Row = row
+			span.Baseline = row + line.baseline
+			rendering.Spans = append(rendering.Spans, span)
+		}
+	}
+
+	rendering.Rows = make([]string, len(text.rows))
+	for idx, row := range text.rows {
+		rendering.Rows[idx] = string(row)
+		if len(row) > rendering.Width {
+			rendering.Width = len(row)
+		}
+	}
+	rendering.Height = len(rendering.Rows)
+
+	return rendering, nil
+}
+
+// String returns rows of rendering separated by line breaks
+func (rendering Rendering) String() string {
+	return strings.Join(rendering.Rows, "\n")
+}
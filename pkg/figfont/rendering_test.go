@@ -0,0 +1,62 @@
+package figfont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFIGFontRender(t *testing.T) {
+	font := testFont(Layout{})
+	rendering, err := font.Render("L\nI", PrintOptions{})
+	assertNoError(t, err)
+
+	expected := Rendering{
+		Rows:     []string{"|  ", "|  ", "|__", " |", " |", " |"},
+		Width:    3,
+		Height:   6,
+		Baseline: 2,
+		Spans: []GlyphSpan{
+			{Index: 0, Rune: 'L', Line: 0, Row: 0, Baseline: 2, Start: 0, End: 3},
+			{Index: 2, Rune: 'I', Line: 1, Row: 3, Baseline: 5, Start: 0, End: 2},
+		},
+	}
+	if !reflect.DeepEqual(expected, rendering) {
+		t.Errorf("want %+v but got %+v", expected, rendering)
+	}
+	assertStringEqual(t, "|  \n|  \n|__\n |\n |\n |", rendering.String())
+}
+
+func TestFIGFontRenderSpans(t *testing.T) {
+	type span struct {
+		index, line, start, end int
+	}
+	testCases := []struct {
+		name   string
+		layout Layout
+		phrase string
+		opts   PrintOptions
+		spans  []span
+	}{
+		{"full width", Layout{}, "LI", PrintOptions{}, []span{{0, 0, 0, 3}, {1, 0, 3, 5}}},
+		{"overlapped by fitting", Layout{Horizontal: Fitting}, "LI", PrintOptions{}, []span{{0, 0, 0, 3}, {1, 0, 2, 4}}},
+		{"right-to-left", Layout{}, "LI", PrintOptions{Direction: RightToLeft}, []span{{0, 0, 2, 5}, {1, 0, 0, 2}}},
+		{"centered", Layout{}, "I", PrintOptions{Width: 7, Justify: JustifyCenter}, []span{{0, 0, 2, 4}}},
+		{"wrapped", Layout{}, "LI LI", PrintOptions{Width: 6}, []span{{0, 0, 0, 3}, {1, 0, 3, 5}, {3, 1, 0, 3}, {4, 1, 3, 5}}},
+		{"transliterated", Layout{}, "ábL", PrintOptions{Transliterate: true}, []span{{0, 0, 0, 3}, {1, 0, 3, 6}, {2, 0, 6, 9}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rendering, err := testFont(testCase.layout).Render(testCase.phrase, testCase.opts)
+			assertNoError(t, err)
+
+			var spans []span
+			for _, glyphSpan := range rendering.Spans {
+				spans = append(spans, span{glyphSpan.Index, glyphSpan.Line, glyphSpan.Start, glyphSpan.End})
+			}
+			if !reflect.DeepEqual(testCase.spans, spans) {
+				t.Errorf("want %v but got %v", testCase.spans, spans)
+			}
+		})
+	}
+}
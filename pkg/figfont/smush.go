@@ -0,0 +1,398 @@
+package figfont
+
+import "strings"
+
+// hierarchyClasses lists classes of smushing hierarchy from the lowest one
+var hierarchyClasses = []string{"|", "/\\", "[]", "{}", "()", "<>"}
+
+// smushChars joins two overlapping sub-characters into one according to
+// horizontal layout. Returns false if these sub-characters can not be smushed
+func smushChars(left, right rune, layout Layout, hardblank rune, rightToLeft bool) (rune, bool) {
+	if left == ' ' {
+		return right, true
+	}
+	if right == ' ' {
+		return left, true
+	}
+	if layout.Horizontal != Smushing {
+		return 0, false
+	}
+
+	rules := layout.HorizontalRules
+	if rules == 0 {
+		// universal smushing: visible sub-character wins, latter one wins over former
+		if left == hardblank {
+			return right, true
+		}
+		if right == hardblank {
+			return left, true
+		}
+		if rightToLeft {
+			return left, true
+		}
+		return right, true
+	}
+
+	if rules.Has(SmushHardblank) && left == hardblank && right == hardblank {
+		return left, true
+	}
+	if left == hardblank || right == hardblank {
+		return 0, false
+	}
+
+	if rules.Has(SmushEqual) && left == right {
+		return left, true
+	}
+
+	if rules.Has(SmushUnderscore) {
+		if left == '_' && strings.ContainsRune("|/\\[]{}()<>", right) {
+			return right, true
+		}
+		if right == '_' && strings.ContainsRune("|/\\[]{}()<>", left) {
+			return left, true
+		}
+	}
+
+	if rules.Has(SmushHierarchy) {
+		leftClass, rightClass := hierarchyClass(left), hierarchyClass(right)
+		if leftClass >= 0 && rightClass >= 0 && leftClass != rightClass {
+			if leftClass > rightClass {
+				return left, true
+			}
+			return right, true
+		}
+	}
+
+	if rules.Has(SmushOppositePair) {
+		switch string([]rune{left, right}) {
+		case "[]", "][", "{}", "}{", "()", ")(":
+			return '|', true
+		}
+	}
+
+	if rules.Has(SmushBigX) {
+		switch string([]rune{left, right}) {
+		case "/\\":
+			return '|', true
+		case "\\/":
+			return 'Y', true
+		case "><":
+			return 'X', true
+		}
+	}
+
+	return 0, false
+}
+
+// hierarchyClass returns position of sub-character in smushing hierarchy
+// or -1 if the sub-character doesn't take part in it
+func hierarchyClass(char rune) int {
+	for class, members := range hierarchyClasses {
+		if strings.ContainsRune(members, char) {
+			return class
+		}
+	}
+
+	return -1
+}
+
+// figLine is a line of FIGcharacters which are assembled one by one
+type figLine struct {
+	rows [][]rune
+	// edges replace rows of line which is only measured, they are ends of
+	// rows which FIGcharacters are added at
+	edges []rowEdge
+	cols  int
+	// chars are characters of line in order of adding, spans are columns
+	// which their FIGcharacters occupy
+	chars       []figChar
+	spans       [][2]int
+	prevWidth   int
+	layout      Layout
+	hardblank   rune
+	rightToLeft bool
+}
+
+// rowEdge is the outermost visible sub-character at an end of row and
+// count of blanks beyond it. Blanks are the whole row if it has no visible
+// sub-characters
+type rowEdge struct {
+	char   rune
+	blanks int
+}
+
+func newFIGLine(height int, layout Layout, hardblank rune, rightToLeft bool) *figLine {
+	return &figLine{
+		rows:        make([][]rune, height),
+		layout:      layout,
+		hardblank:   hardblank,
+		rightToLeft: rightToLeft,
+	}
+}
+
+// newMeasuredLine creates FIG line which keeps only its width and edges
+// of rows, it can't be output
+func newMeasuredLine(height int, layout Layout, hardblank rune, rightToLeft bool) *figLine {
+	return &figLine{
+		edges:       make([]rowEdge, height),
+		layout:      layout,
+		hardblank:   hardblank,
+		rightToLeft: rightToLeft,
+	}
+}
+
+// width of line, all rows of line have equal length
+func (line *figLine) width() int {
+	return line.cols
+}
+
+// height of line
+func (line *figLine) height() int {
+	if line.edges != nil {
+		return len(line.edges)
+	}
+
+	return len(line.rows)
+}
+
+// add places FIGcharacter at the end of line in print direction
+// overlapping it as much as layout allows
+func (line *figLine) add(glyph [][]rune) {
+	glyphWidth := glyphWidth(glyph)
+	amount := line.smushAmount(glyph, glyphWidth)
+
+	for row := range line.edges {
+		line.edges[row] = line.addEdge(line.edges[row], glyph[row], amount, glyphWidth)
+	}
+	for row := range line.rows {
+		left, right := line.sides(row, glyph)
+		joined := left
+		if line.rightToLeft {
+			joined = make([]rune, len(left), len(left)+len(right)-amount)
+			copy(joined, left)
+		}
+
+		for k := 0; k < amount; k++ {
+			column := len(left) - amount + k
+			if smushed, ok := line.smush(left[column], right[k], glyphWidth); ok {
+				joined[column] = smushed
+			}
+		}
+		line.rows[row] = append(joined, right[amount:]...)
+	}
+	line.cols += glyphWidth - amount
+	line.prevWidth = glyphWidth
+}
+
+// addEdge returns edge of line row after glyph row is added overlapping
+// amount columns. The outermost visible sub-character of glyph row is
+// the new edge, it is smushed if it overlaps the edge of line row
+func (line *figLine) addEdge(edge rowEdge, glyphRow []rune, amount, glyphWidth int) rowEdge {
+	outer := findEdge(glyphRow, !line.rightToLeft)
+	if outer.char == ' ' {
+		// blank glyph row leaves the edge of line row visible
+		edge.blanks += glyphWidth - amount
+		return edge
+	}
+
+	if overlap := amount - glyphWidth + outer.blanks; edge.char != ' ' && overlap == edge.blanks {
+		left, right := edge.char, outer.char
+		if line.rightToLeft {
+			left, right = right, left
+		}
+		smushed, ok := line.smush(left, right, glyphWidth)
+		if !ok {
+			smushed = left
+		}
+		outer.char = smushed
+	}
+
+	return outer
+}
+
+// edge returns edge of row at the end which FIGcharacters are added at
+func (line *figLine) edge(row int) rowEdge {
+	if line.edges != nil {
+		return line.edges[row]
+	}
+
+	return findEdge(line.rows[row], !line.rightToLeft)
+}
+
+// findEdge returns edge of row at its end or at its start
+func findEdge(row []rune, atEnd bool) rowEdge {
+	for blanks := range row {
+		char := row[blanks]
+		if atEnd {
+			char = row[len(row)-1-blanks]
+		}
+		if char != ' ' {
+			return rowEdge{char: char, blanks: blanks}
+		}
+	}
+
+	return rowEdge{char: ' ', blanks: len(row)}
+}
+
+// addChar adds FIGcharacter of character to line keeping its columns,
+// columns of measured line are not kept
+func (line *figLine) addChar(char figChar) {
+	before := line.width()
+	line.add(char.glyph)
+	after := line.width()
+	if line.edges != nil {
+		return
+	}
+
+	if line.rightToLeft {
+		// FIGcharacter is placed on the left and moves previous ones
+		shift := after - before
+		for idx := range line.spans {
+			line.spans[idx][0] += shift
+			line.spans[idx][1] += shift
+		}
+		line.spans = append(line.spans, [2]int{0, glyphWidth(char.glyph)})
+	} else {
+		line.spans = append(line.spans, [2]int{after - glyphWidth(char.glyph), after})
+	}
+	line.chars = append(line.chars, char)
+}
+
+// glyphSpans returns positions of FIGcharacters of line shifted by
+// justification
+func (line *figLine) glyphSpans(shift int) []GlyphSpan {
+	spans := make([]GlyphSpan, len(line.chars))
+	for idx, char := range line.chars {
+		spans[idx] = GlyphSpan{
+			Index: char.index,
+			Rune:  char.char,
+			Start: line.spans[idx][0] + shift,
+			End:   line.spans[idx][1] + shift,
+		}
+	}
+
+	return spans
+}
+
+// sides returns row of line and row of glyph in order they are placed
+func (line *figLine) sides(row int, glyph [][]rune) (left, right []rune) {
+	if line.rightToLeft {
+		return glyph[row], line.rows[row]
+	}
+
+	return line.rows[row], glyph[row]
+}
+
+// fits reports whether glyph could be added to the line without exceeding
+// width. Zero width means unlimited line
+func (line *figLine) fits(glyph [][]rune, width int) bool {
+	if width <= 0 {
+		return true
+	}
+	glyphWidth := glyphWidth(glyph)
+
+	return line.width()+glyphWidth-line.smushAmount(glyph, glyphWidth) <= width
+}
+
+// smushAmount calculates how many columns of glyph could overlap the line
+func (line *figLine) smushAmount(glyph [][]rune, glyphWidth int) int {
+	if line.layout.Horizontal == FullWidth {
+		return 0
+	}
+
+	maxSmush := glyphWidth
+	if lineWidth := line.width(); lineWidth < maxSmush {
+		maxSmush = lineWidth
+	}
+	for row := range glyph {
+		lineEdge := line.edge(row)
+		glyphEdge := findEdge(glyph[row], line.rightToLeft)
+
+		amount := lineEdge.blanks + glyphEdge.blanks
+		if lineEdge.char != ' ' && glyphEdge.char != ' ' {
+			left, right := lineEdge.char, glyphEdge.char
+			if line.rightToLeft {
+				left, right = right, left
+			}
+			if _, ok := line.smush(left, right, glyphWidth); ok {
+				amount++
+			}
+		}
+		if amount < maxSmush {
+			maxSmush = amount
+		}
+	}
+
+	return maxSmush
+}
+
+func (line *figLine) smush(left, right rune, glyphWidth int) (rune, bool) {
+	if left != ' ' && right != ' ' && (line.prevWidth < 2 || glyphWidth < 2) {
+		// too narrow FIGcharacters are never overlapped
+		return 0, false
+	}
+
+	return smushChars(left, right, line.layout, line.hardblank, line.rightToLeft)
+}
+
+// output returns rows of line with hardblanks replaced by spaces
+func (line *figLine) output() [][]rune {
+	rows := make([][]rune, len(line.rows))
+	for idx, row := range line.rows {
+		rows[idx] = make([]rune, len(row))
+		for column, char := range row {
+			if char == line.hardblank {
+				char = ' '
+			}
+			rows[idx][column] = char
+		}
+	}
+
+	return rows
+}
+
+// normalizeGlyph converts letter rows to runes and pads them to height
+// and to the same width
+func normalizeGlyph(letter []string, height int) [][]rune {
+	glyph := make([][]rune, height)
+	width := 0
+	for row := 0; row < height && row < len(letter); row++ {
+		glyph[row] = []rune(letter[row])
+		if len(glyph[row]) > width {
+			width = len(glyph[row])
+		}
+	}
+	for row := range glyph {
+		for len(glyph[row]) < width {
+			glyph[row] = append(glyph[row], ' ')
+		}
+	}
+
+	return glyph
+}
+
+// padGlyph adds blank rows above and below FIGcharacter
+func padGlyph(glyph [][]rune, top, bottom int) [][]rune {
+	if top <= 0 && bottom <= 0 {
+		return glyph
+	}
+
+	padded := make([][]rune, 0, top+len(glyph)+bottom)
+	for idx := 0; idx < top; idx++ {
+		padded = append(padded, []rune(strings.Repeat(" ", glyphWidth(glyph))))
+	}
+	padded = append(padded, glyph...)
+	for idx := 0; idx < bottom; idx++ {
+		padded = append(padded, []rune(strings.Repeat(" ", glyphWidth(glyph))))
+	}
+
+	return padded
+}
+
+func glyphWidth(glyph [][]rune) int {
+	if len(glyph) == 0 {
+		return 0
+	}
+
+	return len(glyph[0])
+}
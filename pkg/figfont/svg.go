@@ -0,0 +1,132 @@
+package figfont
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultSVGFontFamily = "monospace"
+	defaultSVGFontSize   = 14
+	// svgCharWidth and svgLineHeight are sizes of text cell relative
+	// to font size, sub-characters are stretched to cell width so text is
+	// aligned with any font
+	svgCharWidth  = 0.6
+	svgLineHeight = 1.2
+)
+
+// SVGOptions are settings of SVG image of rendering
+type SVGOptions struct {
+	// FontFamily is monospace by default
+	FontFamily string
+	// FontSize is size of font in pixels, 14 by default
+	FontSize int
+	// Foreground is color of sub-characters not painted by filters,
+	// black by default. Background is transparent if not set
+	Foreground color.RGBA
+	Background color.RGBA
+	// Padding is space around text in pixels
+	Padding int
+}
+
+// SVG returns rendering as SVG image with a text row per rendering row.
+// Colors set by filters are kept
+func (rendering Rendering) SVG(opts SVGOptions) string {
+	if opts.FontFamily == "" {
+		opts.FontFamily = defaultSVGFontFamily
+	}
+	if opts.FontSize <= 0 {
+		opts.FontSize = defaultSVGFontSize
+	}
+	if opts.Foreground.A == 0 {
+		opts.Foreground = color.RGBA{A: 255}
+	}
+
+	fontSize := float64(opts.FontSize)
+	padding := float64(opts.Padding)
+	charWidth := fontSize * svgCharWidth
+	lineHeight := fontSize * svgLineHeight
+	width := 2*padding + float64(rendering.Width)*charWidth
+	height := 2*padding + float64(len(rendering.Rows))*lineHeight
+
+	var svg strings.Builder
+	fmt.Fprintf(
+		&svg,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s">`+"\n",
+		svgNumber(width),
+		svgNumber(height),
+	)
+	if opts.Background.A != 0 {
+		fmt.Fprintf(&svg, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(opts.Background))
+	}
+	fmt.Fprintf(
+		&svg,
+		`<text font-family="%s" font-size="%d" fill="%s" xml:space="preserve">`+"\n",
+		escapeXML(opts.FontFamily),
+		opts.FontSize,
+		hexColor(opts.Foreground),
+	)
+
+	for row, line := range rendering.Rows {
+		runes := []rune(line)
+		fmt.Fprintf(
+			&svg,
+			`<tspan x="%s" y="%s"`,
+			svgNumber(padding),
+			svgNumber(padding+float64(row)*lineHeight+fontSize),
+		)
+		if len(runes) > 0 {
+			fmt.Fprintf(
+				&svg,
+				` textLength="%s" lengthAdjust="spacingAndGlyphs"`,
+				svgNumber(float64(len(runes))*charWidth),
+			)
+		}
+		svg.WriteString(">")
+		writeSVGRow(&svg, runes, rendering.rowColors(row))
+		svg.WriteString("</tspan>\n")
+	}
+	svg.WriteString("</text>\n</svg>\n")
+
+	return svg.String()
+}
+
+// writeSVGRow writes row wrapping runs of painted sub-characters
+// in tspans with fill color
+func writeSVGRow(svg *strings.Builder, row []rune, colors []color.RGBA) {
+	start := 0
+	for start < len(row) {
+		runColor := cellColor(row, colors, start)
+		end := start + 1
+		for end < len(row) && cellColor(row, colors, end) == runColor {
+			end++
+		}
+
+		text := escapeXML(string(row[start:end]))
+		if runColor.A == 0 {
+			svg.WriteString(text)
+		} else {
+			fmt.Fprintf(svg, `<tspan fill="%s">%s</tspan>`, hexColor(runColor), text)
+		}
+		start = end
+	}
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func svgNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func escapeXML(text string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(text))
+
+	return escaped.String()
+}
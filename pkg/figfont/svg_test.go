@@ -0,0 +1,53 @@
+package figfont
+
+import (
+	"encoding/xml"
+	"image/color"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRenderingSVG(t *testing.T) {
+	rendering := Rendering{Rows: []string{"<a>", "", " &"}, Width: 3, Height: 3}
+
+	expected := `<svg xmlns="http://www.w3.org/2000/svg" width="34" height="52" viewBox="0 0 34 52">
+<text font-family="monospace" font-size="10" fill="#000000" xml:space="preserve">
+<tspan x="8" y="18" textLength="18" lengthAdjust="spacingAndGlyphs">&lt;a&gt;</tspan>
+<tspan x="8" y="30"></tspan>
+<tspan x="8" y="42" textLength="12" lengthAdjust="spacingAndGlyphs"> &amp;</tspan>
+</text>
+</svg>
+`
+	assertStringEqual(t, expected, rendering.SVG(SVGOptions{FontSize: 10, Padding: 8}))
+}
+
+func TestRenderingSVGColors(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	rendering := Rendering{Rows: []string{"ab c"}, Width: 4, Height: 1}.Apply(Gradient(red, red))
+	opts := SVGOptions{
+		FontFamily: `"Fira Code", monospace`,
+		Foreground: color.RGBA{0, 0, 255, 255},
+		Background: color.RGBA{255, 255, 255, 255},
+	}
+
+	expected := `<svg xmlns="http://www.w3.org/2000/svg" width="33.6" height="16.8" viewBox="0 0 33.6 16.8">
+<rect width="100%" height="100%" fill="#ffffff"/>
+<text font-family="&#34;Fira Code&#34;, monospace" font-size="14" fill="#0000ff" xml:space="preserve">
+<tspan x="0" y="14" textLength="33.6" lengthAdjust="spacingAndGlyphs"><tspan fill="#ff0000">ab</tspan> <tspan fill="#ff0000">c</tspan></tspan>
+</text>
+</svg>
+`
+	svg := rendering.SVG(opts)
+	assertStringEqual(t, expected, svg)
+
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if err != io.EOF {
+				t.Errorf("SVG is not well-formed: %v", err)
+			}
+			break
+		}
+	}
+}
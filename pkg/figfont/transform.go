@@ -0,0 +1,281 @@
+package figfont
+
+import "image/color"
+
+// flipChars are sub-characters replaced by their horizontal mirrors
+var flipChars = mirrorChars("/\\", "()", "[]", "{}", "<>", "bd", "pq")
+
+// flopChars are sub-characters replaced by their vertical mirrors
+var flopChars = mirrorChars("/\\", "_‾", "^v", "bp", "dq", "MW", "mw", "',")
+
+// mirrorChars maps both characters of every pair to each other
+func mirrorChars(pairs ...string) map[rune]rune {
+	chars := make(map[rune]rune)
+	for _, pair := range pairs {
+		runes := []rune(pair)
+		chars[runes[0]] = runes[1]
+		chars[runes[1]] = runes[0]
+	}
+
+	return chars
+}
+
+// Flip mirrors rendering horizontally, sub-characters like slashes and
+// brackets are replaced by their mirrors
+func Flip() Filter {
+	return func(rendering Rendering) Rendering {
+		cells, colors := rendering.grid()
+		for row := range cells {
+			reverseRunes(cells[row])
+			for col, char := range cells[row] {
+				if mirror, ok := flipChars[char]; ok {
+					cells[row][col] = mirror
+				}
+			}
+			if colors != nil {
+				reverseColors(colors[row])
+			}
+		}
+
+		spans := moveSpans(rendering.Spans, func(span *GlyphSpan) {
+			span.Start, span.End = rendering.Width-span.End, rendering.Width-span.Start
+		})
+
+		return rendering.withGrid(cells, colors, spans)
+	}
+}
+
+// Flop mirrors rendering vertically, sub-characters like underscores and
+// carets are replaced by their mirrors. FIG lines are turned upside down
+// so spans are dropped
+func Flop() Filter {
+	return func(rendering Rendering) Rendering {
+		cells, colors := rendering.grid()
+		for top, bottom := 0, len(cells)-1; top < bottom; top, bottom = top+1, bottom-1 {
+			cells[top], cells[bottom] = cells[bottom], cells[top]
+			if colors != nil {
+				colors[top], colors[bottom] = colors[bottom], colors[top]
+			}
+		}
+		for _, row := range cells {
+			for col, char := range row {
+				if mirror, ok := flopChars[char]; ok {
+					row[col] = mirror
+				}
+			}
+		}
+
+		flopped := rendering.withGrid(cells, colors, nil)
+		flopped.Baseline = rendering.Height - 1 - rendering.Baseline
+
+		return flopped
+	}
+}
+
+// Rotate180 turns rendering upside down
+func Rotate180() Filter {
+	return func(rendering Rendering) Rendering {
+		return rendering.Apply(Flip(), Flop())
+	}
+}
+
+// Crop removes blank rows and columns around rendering
+func Crop() Filter {
+	return func(rendering Rendering) Rendering {
+		cells, colors := rendering.grid()
+		top, bottom := 0, len(cells)
+		for top < bottom && isBlank(cells[top]) {
+			top++
+		}
+		for bottom > top && isBlank(cells[bottom-1]) {
+			bottom--
+		}
+
+		left, right := rendering.Width, 0
+		for _, row := range cells[top:bottom] {
+			for col, char := range row {
+				if char == ' ' {
+					continue
+				}
+				if col < left {
+					left = col
+				}
+				if col+1 > right {
+					right = col + 1
+				}
+			}
+		}
+		if left > right {
+			left = right
+		}
+
+		cells = cells[top:bottom]
+		for row := range cells {
+			cells[row] = cells[row][left:right]
+		}
+		if colors != nil {
+			colors = colors[top:bottom]
+			for row := range colors {
+				colors[row] = colors[row][left:right]
+			}
+		}
+
+		spans := moveSpans(rendering.Spans, func(span *GlyphSpan) {
+			span.Row -= top
+			span.Baseline -= top
+			span.Start = clamp(span.Start-left, 0, right-left)
+			span.End = clamp(span.End-left, 0, right-left)
+		})
+
+		cropped := rendering.withGrid(cells, colors, spans)
+		cropped.Baseline = rendering.Baseline - top
+
+		return cropped
+	}
+}
+
+// Border draws box around rendering
+func Border() Filter {
+	return func(rendering Rendering) Rendering {
+		cells, colors := rendering.grid()
+		width := rendering.Width + 2
+
+		bordered := make([][]rune, 0, len(cells)+2)
+		bordered = append(bordered, borderRow('+', '-', width))
+		for _, row := range cells {
+			line := make([]rune, 0, width)
+			line = append(line, '|')
+			line = append(line, row...)
+			line = append(line, '|')
+			bordered = append(bordered, line)
+		}
+		bordered = append(bordered, borderRow('+', '-', width))
+
+		var borderedColors [][]color.RGBA
+		if colors != nil {
+			borderedColors = make([][]color.RGBA, 0, len(colors)+2)
+			borderedColors = append(borderedColors, make([]color.RGBA, width))
+			for _, row := range colors {
+				line := make([]color.RGBA, 0, width)
+				line = append(line, color.RGBA{})
+				line = append(line, row...)
+				line = append(line, color.RGBA{})
+				borderedColors = append(borderedColors, line)
+			}
+			borderedColors = append(borderedColors, make([]color.RGBA, width))
+		}
+
+		spans := moveSpans(rendering.Spans, func(span *GlyphSpan) {
+			span.Row++
+			span.Baseline++
+			span.Start++
+			span.End++
+		})
+
+		framed := rendering.withGrid(bordered, borderedColors, spans)
+		framed.Baseline = rendering.Baseline + 1
+
+		return framed
+	}
+}
+
+// grid returns copy of rows and colors of rendering padded by blanks
+// to its width. Colors are nil if rendering isn't painted
+func (rendering Rendering) grid() ([][]rune, [][]color.RGBA) {
+	cells := make([][]rune, len(rendering.Rows))
+	for row, line := range rendering.Rows {
+		cells[row] = make([]rune, rendering.Width)
+		for col := range cells[row] {
+			cells[row][col] = ' '
+		}
+		copy(cells[row], []rune(line))
+	}
+
+	if rendering.Colors == nil {
+		return cells, nil
+	}
+	colors := make([][]color.RGBA, len(rendering.Rows))
+	for row := range colors {
+		colors[row] = make([]color.RGBA, rendering.Width)
+		if row < len(rendering.Colors) {
+			copy(colors[row], rendering.Colors[row])
+		}
+	}
+
+	return cells, colors
+}
+
+// withGrid returns rendering with rows, colors and spans replaced
+func (rendering Rendering) withGrid(cells [][]rune, colors [][]color.RGBA, spans []GlyphSpan) Rendering {
+	rendering.Rows = make([]string, len(cells))
+	rendering.Width = 0
+	for row, line := range cells {
+		rendering.Rows[row] = string(line)
+		if len(line) > rendering.Width {
+			rendering.Width = len(line)
+		}
+	}
+	rendering.Height = len(cells)
+	rendering.Colors = colors
+	rendering.Spans = spans
+
+	return rendering
+}
+
+// moveSpans returns copy of spans changed by move
+func moveSpans(spans []GlyphSpan, move func(span *GlyphSpan)) []GlyphSpan {
+	if spans == nil {
+		return nil
+	}
+
+	moved := make([]GlyphSpan, len(spans))
+	for idx, span := range spans {
+		move(&span)
+		moved[idx] = span
+	}
+
+	return moved
+}
+
+func borderRow(corner, edge rune, width int) []rune {
+	row := make([]rune, width)
+	for col := range row {
+		row[col] = edge
+	}
+	row[0], row[width-1] = corner, corner
+
+	return row
+}
+
+func isBlank(row []rune) bool {
+	for _, char := range row {
+		if char != ' ' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func reverseRunes(row []rune) {
+	for left, right := 0, len(row)-1; left < right; left, right = left+1, right-1 {
+		row[left], row[right] = row[right], row[left]
+	}
+}
+
+func reverseColors(row []color.RGBA) {
+	for left, right := 0, len(row)-1; left < right; left, right = left+1, right-1 {
+		row[left], row[right] = row[right], row[left]
+	}
+}
+
+func clamp(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+
+	return value
+}
@@ -0,0 +1,108 @@
+package figfont
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestTransformFilters(t *testing.T) {
+	rendering := Rendering{
+		Rows:     []string{"", " /_", " (^<", ""},
+		Width:    4,
+		Height:   4,
+		Baseline: 2,
+	}
+
+	testCases := []struct {
+		name     string
+		filters  []Filter
+		rows     []string
+		width    int
+		baseline int
+	}{
+		{"flip", []Filter{Flip()}, []string{"    ", " _\\ ", ">^) ", "    "}, 4, 2},
+		{"flop", []Filter{Flop()}, []string{"    ", " (v<", " \\‾ ", "    "}, 4, 1},
+		{"rotate", []Filter{Rotate180()}, []string{"    ", ">v) ", " ‾/ ", "    "}, 4, 1},
+		{"crop", []Filter{Crop()}, []string{"/_ ", "(^<"}, 3, 1},
+		{"border", []Filter{Crop(), Border()}, []string{"+---+", "|/_ |", "|(^<|", "+---+"}, 5, 2},
+		{"crop twice", []Filter{Crop(), Crop()}, []string{"/_ ", "(^<"}, 3, 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transformed := rendering.Apply(testCase.filters...)
+			if !reflect.DeepEqual(testCase.rows, transformed.Rows) {
+				t.Errorf("want %q but got %q", testCase.rows, transformed.Rows)
+			}
+			assertIntEqual(t, testCase.width, transformed.Width)
+			assertIntEqual(t, len(testCase.rows), transformed.Height)
+			assertIntEqual(t, testCase.baseline, transformed.Baseline)
+		})
+	}
+}
+
+func TestTransformFiltersBlankRendering(t *testing.T) {
+	rendering := Rendering{Rows: []string{"  ", ""}, Width: 2, Height: 2}.Apply(Crop(), Border())
+	if !reflect.DeepEqual([]string{"++", "++"}, rendering.Rows) {
+		t.Errorf("unexpected rows %q", rendering.Rows)
+	}
+}
+
+func TestTransformFiltersSpans(t *testing.T) {
+	font := testFont(Layout{})
+	rendering, err := font.Render("LI", PrintOptions{})
+	assertNoError(t, err)
+
+	testCases := []struct {
+		name   string
+		filter Filter
+		spans  []GlyphSpan
+	}{
+		{
+			"flip",
+			Flip(),
+			[]GlyphSpan{
+				{Index: 0, Rune: 'L', Baseline: 2, Start: 2, End: 5},
+				{Index: 1, Rune: 'I', Baseline: 2, Start: 0, End: 2},
+			},
+		},
+		{
+			"border",
+			Border(),
+			[]GlyphSpan{
+				{Index: 0, Rune: 'L', Row: 1, Baseline: 3, Start: 1, End: 4},
+				{Index: 1, Rune: 'I', Row: 1, Baseline: 3, Start: 4, End: 6},
+			},
+		},
+		{"flop", Flop(), nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transformed := rendering.Apply(testCase.filter)
+			if !reflect.DeepEqual(testCase.spans, transformed.Spans) {
+				t.Errorf("want %+v but got %+v", testCase.spans, transformed.Spans)
+			}
+		})
+	}
+}
+
+func TestTransformFiltersColors(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	rendering := Rendering{Rows: []string{"ab", "c"}, Width: 2, Height: 2}.Apply(Gradient(red, red))
+
+	flipped := rendering.Apply(Flip(), Border())
+	expected := [][]color.RGBA{
+		{{}, {}, {}, {}},
+		{{}, red, red, {}},
+		{{}, {}, red, {}},
+		{{}, {}, {}, {}},
+	}
+	if !reflect.DeepEqual(expected, flipped.Colors) {
+		t.Errorf("want %v but got %v", expected, flipped.Colors)
+	}
+	if !reflect.DeepEqual([]string{"ab", "c"}, rendering.Rows) {
+		t.Errorf("filters modified source rendering")
+	}
+}
@@ -0,0 +1,50 @@
+package figfont
+
+import (
+	"testing"
+)
+
+func TestFIGFontPrintWithOptionsTransliterate(t *testing.T) {
+	font := FIGFont{
+		Hardblank: "$",
+		Height:    1,
+		Letters: map[int][]string{
+			' ': {"$"},
+			'Z': {"Z"},
+			'h': {"h"},
+			's': {"s"},
+			'e': {"e"},
+		},
+	}
+
+	testCases := []struct {
+		phrase string
+		result string
+	}{
+		{"z", "Z"},
+		{"H", "h"},
+		{"Ж", "Zh"},
+		{"ж", "Zh"},
+		{"ß", "ss"},
+		{"É", "e"},
+		{"Zь", "Z"},
+		{"Zé he", "Ze he"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.phrase, func(t *testing.T) {
+			printed, err := font.PrintWithOptions(testCase.phrase, PrintOptions{Transliterate: true})
+			assertNoError(t, err)
+			assertStringEqual(t, testCase.result, printed)
+		})
+	}
+
+	t.Run("replacement is absent", func(t *testing.T) {
+		_, err := font.PrintWithOptions("Ц", PrintOptions{Transliterate: true})
+		assertError(t, err, "unknown letter '1062'")
+	})
+	t.Run("disabled", func(t *testing.T) {
+		_, err := font.PrintWithOptions("é", PrintOptions{})
+		assertError(t, err, "unknown letter '233'")
+	})
+}
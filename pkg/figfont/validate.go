@@ -0,0 +1,173 @@
+package figfont
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidationIssue is a problem found in font by Validate
+type ValidationIssue struct {
+	// Glyph reports whether issue is related to FIGcharacter with Code
+	Glyph   bool   `json:"glyph"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (issue ValidationIssue) String() string {
+	if issue.Glyph {
+		return fmt.Sprintf("glyph %d: %s", issue.Code, issue.Message)
+	}
+
+	return issue.Message
+}
+
+// ValidationReport contains problems of font. Font with errors renders
+// incorrectly, warnings point to deviations from FIGfont specification
+// which are tolerated by printing
+type ValidationReport struct {
+	Errors   []ValidationIssue `json:"errors"`
+	Warnings []ValidationIssue `json:"warnings"`
+}
+
+// HasErrors reports whether font has errors
+func (report ValidationReport) HasErrors() bool {
+	return len(report.Errors) > 0
+}
+
+func (report *ValidationReport) errorf(format string, args ...interface{}) {
+	report.Errors = append(report.Errors, ValidationIssue{Message: fmt.Sprintf(format, args...)})
+}
+
+func (report *ValidationReport) warnf(format string, args ...interface{}) {
+	report.Warnings = append(report.Warnings, ValidationIssue{Message: fmt.Sprintf(format, args...)})
+}
+
+func (report *ValidationReport) glyphErrorf(code int, format string, args ...interface{}) {
+	report.Errors = append(report.Errors, ValidationIssue{true, code, fmt.Sprintf(format, args...)})
+}
+
+func (report *ValidationReport) glyphWarnf(code int, format string, args ...interface{}) {
+	report.Warnings = append(report.Warnings, ValidationIssue{true, code, fmt.Sprintf(format, args...)})
+}
+
+// Validate checks font for consistency with its header and FIGfont
+// specification
+func Validate(font FIGFont) ValidationReport {
+	var report ValidationReport
+
+	validateHeader(font, &report)
+	validateRequiredChars(font, &report)
+
+	codes := make([]int, 0, len(font.Letters))
+	for code := range font.Letters {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		validateGlyph(font, code, &report)
+	}
+
+	return report
+}
+
+func validateHeader(font FIGFont, report *ValidationReport) {
+	if font.Height < 1 {
+		report.errorf("Height %d is less than 1", font.Height)
+	}
+	if font.Baseline < 1 || font.Baseline > font.Height {
+		report.errorf("Baseline %d is out of range 1-%d", font.Baseline, font.Height)
+	}
+
+	hardblank := []rune(font.Hardblank)
+	switch {
+	case len(hardblank) != 1:
+		report.errorf("hardblank '%s' is not a single character", font.Hardblank)
+	case hardblank[0] == ' ':
+		report.warnf("hardblank is space so it can't be told apart from blank")
+	}
+
+	// Codetag_Count is optional so zero count is not checked
+	tagged := 0
+	isRequired := make(map[int]bool)
+	for _, code := range requiredCharCodes() {
+		isRequired[code] = true
+	}
+	for code := range font.Letters {
+		if !isRequired[code] {
+			tagged++
+		}
+	}
+	if font.CodetagCount != 0 && font.CodetagCount != tagged {
+		report.warnf("Codetag_Count is %d but font has %d code tagged characters", font.CodetagCount, tagged)
+	}
+}
+
+func validateRequiredChars(font FIGFont, report *ValidationReport) {
+	for code := 32; code <= 126; code++ {
+		if _, ok := font.Letters[code]; !ok {
+			report.glyphErrorf(code, "missing required ASCII character")
+		}
+	}
+	for _, code := range deutschCodes {
+		if _, ok := font.Letters[code]; !ok {
+			report.glyphWarnf(code, "missing required Deutsch character")
+		}
+	}
+}
+
+func validateGlyph(font FIGFont, code int, report *ValidationReport) {
+	letter := font.Letters[code]
+	if len(letter) != font.Height {
+		report.glyphErrorf(code, "has %d rows but Height is %d", len(letter), font.Height)
+	}
+
+	hardblank := font.hardblank()
+	width := -1
+	for idx, row := range letter {
+		runes := []rune(row)
+		if width >= 0 && len(runes) != width {
+			report.glyphWarnf(code, "row %d is %d characters wide but row 1 is %d", idx+1, len(runes), width)
+		}
+		if width < 0 {
+			width = len(runes)
+		}
+
+		// endmarks are part of line, the last row is ended by two of them
+		length := len(runes) + 1
+		if idx == len(letter)-1 {
+			length++
+		}
+		if font.MaxLength > 0 && length > font.MaxLength {
+			report.glyphWarnf(code, "row %d is %d characters long but Max_Length is %d", idx+1, length, font.MaxLength)
+		}
+
+		if hardblank != 0 && hardblank != ' ' && isHardblankDrawn(runes, hardblank) {
+			report.glyphWarnf(code, "row %d uses hardblank between visible characters, it is printed as blank", idx+1)
+		}
+	}
+
+	if code == ' ' && hardblank != 0 && hardblank != ' ' && font.Layout.Horizontal == Smushing &&
+		!strings.ContainsRune(strings.Join(letter, ""), hardblank) {
+		report.glyphWarnf(code, "space has no hardblanks so it is smushed away")
+	}
+}
+
+// isHardblankDrawn reports whether hardblank is placed between visible
+// characters so it looks like a part of drawing rather than blank
+func isHardblankDrawn(row []rune, hardblank rune) bool {
+	for idx := 1; idx < len(row)-1; idx++ {
+		if row[idx] != hardblank {
+			continue
+		}
+		if isVisible(row[idx-1], hardblank) && isVisible(row[idx+1], hardblank) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isVisible(char, hardblank rune) bool {
+	return char != ' ' && char != hardblank
+}
@@ -0,0 +1,124 @@
+package figfont
+
+import (
+	"reflect"
+	"testing"
+)
+
+func validFont() FIGFont {
+	font := FIGFont{
+		Hardblank: "$",
+		Height:    2,
+		Baseline:  2,
+		MaxLength: 4,
+		Layout:    Layout{Horizontal: Smushing},
+		Letters:   map[int][]string{},
+	}
+	for _, code := range requiredCharCodes() {
+		font.Letters[code] = []string{"ab", "cd"}
+	}
+	font.Letters[' '] = []string{"$", "$"}
+
+	return font
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modify   func(font *FIGFont)
+		errors   []ValidationIssue
+		warnings []ValidationIssue
+	}{
+		{"valid font", func(font *FIGFont) {}, nil, nil},
+		{
+			"inconsistent glyph width",
+			func(font *FIGFont) { font.Letters['A'] = []string{"ab", "c"} },
+			nil,
+			[]ValidationIssue{{true, 'A', "row 2 is 1 characters wide but row 1 is 2"}},
+		},
+		{
+			"row count differs from height",
+			func(font *FIGFont) { font.Letters['A'] = []string{"ab"} },
+			[]ValidationIssue{{true, 'A', "has 1 rows but Height is 2"}},
+			nil,
+		},
+		{
+			"baseline greater than height",
+			func(font *FIGFont) { font.Baseline = 3 },
+			[]ValidationIssue{{false, 0, "Baseline 3 is out of range 1-2"}},
+			nil,
+		},
+		{
+			"missing required characters",
+			func(font *FIGFont) {
+				delete(font.Letters, 'A')
+				delete(font.Letters, 223)
+			},
+			[]ValidationIssue{{true, 'A', "missing required ASCII character"}},
+			[]ValidationIssue{{true, 223, "missing required Deutsch character"}},
+		},
+		{
+			"code tag count",
+			func(font *FIGFont) {
+				font.CodetagCount = 2
+				font.Letters[1058] = []string{"ab", "cd"}
+			},
+			nil,
+			[]ValidationIssue{{false, 0, "Codetag_Count is 2 but font has 1 code tagged characters"}},
+		},
+		{
+			"line exceeds max length",
+			func(font *FIGFont) { font.Letters['A'] = []string{"abc", "abc"} },
+			nil,
+			[]ValidationIssue{{true, 'A', "row 2 is 5 characters long but Max_Length is 4"}},
+		},
+		{
+			"hardblank at glyph edge",
+			func(font *FIGFont) { font.Letters['$'] = []string{"|$", "$|"} },
+			nil,
+			nil,
+		},
+		{
+			"hardblank used as drawing",
+			func(font *FIGFont) {
+				font.MaxLength = 5
+				font.Letters['$'] = []string{"($)", "($)"}
+			},
+			nil,
+			[]ValidationIssue{
+				{true, '$', "row 1 uses hardblank between visible characters, it is printed as blank"},
+				{true, '$', "row 2 uses hardblank between visible characters, it is printed as blank"},
+			},
+		},
+		{
+			"space without hardblanks",
+			func(font *FIGFont) { font.Letters[' '] = []string{" ", " "} },
+			nil,
+			[]ValidationIssue{{true, ' ', "space has no hardblanks so it is smushed away"}},
+		},
+		{
+			"bad hardblank",
+			func(font *FIGFont) { font.Hardblank = "" },
+			[]ValidationIssue{{false, 0, "hardblank '' is not a single character"}},
+			nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			font := validFont()
+			testCase.modify(&font)
+
+			report := Validate(font)
+			if !reflect.DeepEqual(testCase.errors, report.Errors) {
+				t.Errorf("errors:\nexpect: %v\n   got: %v", testCase.errors, report.Errors)
+			}
+			if !reflect.DeepEqual(testCase.warnings, report.Warnings) {
+				t.Errorf("warnings:\nexpect: %v\n   got: %v", testCase.warnings, report.Warnings)
+			}
+			if report.HasErrors() != (len(testCase.errors) > 0) {
+				t.Errorf("unexpected HasErrors result")
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package figfont
+
+import (
+	"strings"
+)
+
+// smushVertical joins two overlapping sub-characters of upper and lower
+// FIG lines according to vertical layout. Returns false if these
+// sub-characters can not be smushed
+func smushVertical(upper, lower rune, layout Layout) (rune, bool) {
+	if upper == ' ' {
+		return lower, true
+	}
+	if lower == ' ' {
+		return upper, true
+	}
+	if layout.Vertical != Smushing {
+		return 0, false
+	}
+
+	rules := layout.VerticalRules
+	if rules == 0 {
+		// universal smushing: lower sub-character wins
+		return lower, true
+	}
+
+	if rules.Has(VSmushEqual) && upper == lower {
+		return upper, true
+	}
+
+	if rules.Has(VSmushUnderscore) {
+		if upper == '_' && strings.ContainsRune("|/\\[]{}()<>", lower) {
+			return lower, true
+		}
+		if lower == '_' && strings.ContainsRune("|/\\[]{}()<>", upper) {
+			return upper, true
+		}
+	}
+
+	if rules.Has(VSmushHierarchy) {
+		upperClass, lowerClass := hierarchyClass(upper), hierarchyClass(lower)
+		if upperClass >= 0 && lowerClass >= 0 && upperClass != lowerClass {
+			if upperClass > lowerClass {
+				return upper, true
+			}
+			return lower, true
+		}
+	}
+
+	if rules.Has(VSmushHorizontalLine) {
+		if (upper == '-' && lower == '_') || (upper == '_' && lower == '-') {
+			return '=', true
+		}
+	}
+
+	if rules.Has(VSmushVerticalLine) && upper == '|' && lower == '|' {
+		return '|', true
+	}
+
+	return 0, false
+}
+
+// figText is a block of FIG lines stacked one below another
+type figText struct {
+	rows   [][]rune
+	layout Layout
+}
+
+func newFIGText(layout Layout) *figText {
+	return &figText{layout: layout}
+}
+
+// add places FIG line below the text overlapping it as much as
+// vertical layout allows and returns the first row of line in text.
+// Line must not contain hardblanks
+func (text *figText) add(line [][]rune) int {
+	amount := text.overlapAmount(line)
+	start := len(text.rows) - amount
+
+	for row, lineRow := range line {
+		if row < amount {
+			text.rows[start+row] = smushRows(text.rows[start+row], lineRow, text.layout)
+		} else {
+			text.rows = append(text.rows, lineRow)
+		}
+	}
+
+	return start
+}
+
+// overlapAmount calculates how many rows of line could overlap the text
+func (text *figText) overlapAmount(line [][]rune) int {
+	if text.layout.Vertical == FullWidth {
+		return 0
+	}
+
+	maxOverlap := len(line)
+	if len(text.rows) < maxOverlap {
+		maxOverlap = len(text.rows)
+	}
+
+	amount := 0
+	for next := 1; next <= maxOverlap; next++ {
+		smushed := false
+		for row := 0; row < next; row++ {
+			upperRow := text.rows[len(text.rows)-next+row]
+			lowerRow := line[row]
+			for column := 0; column < len(upperRow) && column < len(lowerRow); column++ {
+				upper, lower := upperRow[column], lowerRow[column]
+				if upper == ' ' || lower == ' ' {
+					continue
+				}
+				if _, ok := smushVertical(upper, lower, text.layout); !ok {
+					return amount
+				}
+				superSmush := text.layout.VerticalRules.Has(VSmushVerticalLine) && upper == '|' && lower == '|'
+				if !superSmush {
+					smushed = true
+				}
+			}
+		}
+
+		amount = next
+		if smushed {
+			// only one row of visible sub-characters is smushed, except vertical lines
+			break
+		}
+	}
+
+	return amount
+}
+
+// smushRows overlaps two rows of sub-characters column by column
+func smushRows(upperRow, lowerRow []rune, layout Layout) []rune {
+	width := len(upperRow)
+	if len(lowerRow) > width {
+		width = len(lowerRow)
+	}
+
+	smushed := make([]rune, width)
+	for column := range smushed {
+		upper, lower := ' ', ' '
+		if column < len(upperRow) {
+			upper = upperRow[column]
+		}
+		if column < len(lowerRow) {
+			lower = lowerRow[column]
+		}
+
+		if char, ok := smushVertical(upper, lower, layout); ok {
+			smushed[column] = char
+		} else {
+			smushed[column] = lower
+		}
+	}
+
+	return smushed
+}
+
+// String returns rendered text
+func (text *figText) String() string {
+	rows := make([]string, len(text.rows))
+	for idx, row := range text.rows {
+		rows[idx] = string(row)
+	}
+
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,133 @@
+package figfont
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFIGTextAdd(t *testing.T) {
+	testCases := []struct {
+		name   string
+		layout Layout
+		upper  []string
+		lower  []string
+		result string
+	}{
+		{
+			"full width",
+			Layout{},
+			[]string{"abc", "-_-"},
+			[]string{"_-_", "xyz"},
+			"abc\n-_-\n_-_\nxyz",
+		},
+		{
+			"fitting with collision",
+			Layout{Vertical: Fitting},
+			[]string{"abc", "-_-"},
+			[]string{"_-_", "xyz"},
+			"abc\n-_-\n_-_\nxyz",
+		},
+		{
+			"fitting",
+			Layout{Vertical: Fitting},
+			[]string{" /\\ ", "/  \\"},
+			[]string{"    ", " || "},
+			" /\\ \n/||\\",
+		},
+		{
+			"universal smushing",
+			Layout{Vertical: Smushing},
+			[]string{"abc", "-_-"},
+			[]string{"_-_", "xyz"},
+			"abc\n_-_\nxyz",
+		},
+		{
+			"horizontal line smushing",
+			Layout{Vertical: Smushing, VerticalRules: VSmushHorizontalLine},
+			[]string{"abc", "-_-"},
+			[]string{"_-_", "xyz"},
+			"abc\n===\nxyz",
+		},
+		{
+			"smushing without matching rule",
+			Layout{Vertical: Smushing, VerticalRules: VSmushEqual},
+			[]string{"abc", "-_-"},
+			[]string{"_-_", "xyz"},
+			"abc\n-_-\n_-_\nxyz",
+		},
+		{
+			"vertical line supersmushing",
+			Layout{Vertical: Smushing, VerticalRules: VSmushVerticalLine},
+			[]string{"|", "|"},
+			[]string{"|", "|"},
+			"|\n|",
+		},
+		{
+			"vertical line equal smushing",
+			Layout{Vertical: Smushing, VerticalRules: VSmushEqual},
+			[]string{"|", "|"},
+			[]string{"|", "|"},
+			"|\n|\n|",
+		},
+		{
+			"different widths",
+			Layout{Vertical: Fitting},
+			[]string{"ab", "  "},
+			[]string{"    ", "cdef"},
+			"ab  \ncdef",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			text := newFIGText(testCase.layout)
+			text.add(runeRows(testCase.upper))
+			text.add(runeRows(testCase.lower))
+			assertStringEqual(t, testCase.result, text.String())
+		})
+	}
+}
+
+func TestSmushVertical(t *testing.T) {
+	testCases := []struct {
+		rules  SmushRule
+		upper  rune
+		lower  rune
+		result rune
+		ok     bool
+	}{
+		{0, 'a', 'b', 'b', true},
+		{VSmushEqual, 'a', ' ', 'a', true},
+		{VSmushEqual, 'a', 'a', 'a', true},
+		{VSmushEqual, 'a', 'b', 0, false},
+		{VSmushUnderscore, '_', '|', '|', true},
+		{VSmushUnderscore, '}', '_', '}', true},
+		{VSmushHierarchy, '/', '<', '<', true},
+		{VSmushHierarchy, '{', '|', '{', true},
+		{VSmushHorizontalLine, '-', '_', '=', true},
+		{VSmushHorizontalLine, '_', '-', '=', true},
+		{VSmushHorizontalLine, '-', '-', 0, false},
+		{VSmushVerticalLine, '|', '|', '|', true},
+		{VSmushVerticalLine, '|', '/', 0, false},
+	}
+
+	for _, testCase := range testCases {
+		caseName := fmt.Sprintf("%d %c%c", testCase.rules, testCase.upper, testCase.lower)
+		t.Run(caseName, func(t *testing.T) {
+			layout := Layout{Vertical: Smushing, VerticalRules: testCase.rules}
+			result, ok := smushVertical(testCase.upper, testCase.lower, layout)
+			if ok != testCase.ok || result != testCase.result {
+				t.Errorf("want '%c' %v but got '%c' %v", testCase.result, testCase.ok, result, ok)
+			}
+		})
+	}
+}
+
+func runeRows(rows []string) [][]rune {
+	runes := make([][]rune, len(rows))
+	for idx, row := range rows {
+		runes[idx] = []rune(row)
+	}
+
+	return runes
+}